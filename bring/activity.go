@@ -0,0 +1,125 @@
+package bring
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ActivityEvent is a single entry of a list's activity timeline.
+type ActivityEvent struct {
+	// UUID identifies the event and is used as module UUID for reactions.
+	UUID           string          `json:"uuid,omitempty"`
+	Type           ActivityType    `json:"type"`
+	Time           time.Time       `json:"time"`
+	PublicUserUUID string          `json:"publicUserUuid,omitempty"`
+	Items          []ActivityItem  `json:"items,omitempty"`
+	Raw            json.RawMessage `json:"raw,omitempty"`
+}
+
+// ActivityItem is an item touched by an activity event.
+type ActivityItem struct {
+	ItemID string `json:"itemId"`
+	Spec   string `json:"spec,omitempty"`
+	UUID   string `json:"uuid,omitempty"`
+	// Status tells where the item ended up: BringItemToPurchase or
+	// BringItemToRecently. It is empty when the API does not say.
+	Status BringItemOperation `json:"status,omitempty"`
+}
+
+var activityTimeLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05.999Z0700", "2006-01-02T15:04:05"}
+
+// UnmarshalJSON reads API events, where details are nested in "content",
+// and also the flat form ActivityEvent marshals to, so events round-trip.
+func (e *ActivityEvent) UnmarshalJSON(b []byte) error {
+	var event map[string]interface{}
+	if err := json.Unmarshal(b, &event); err != nil {
+		return err
+	}
+	content, _ := event["content"].(map[string]interface{})
+	if content == nil {
+		content = map[string]interface{}{}
+	}
+
+	*e = ActivityEvent{
+		UUID:           firstString(content, "uuid"),
+		Type:           ActivityType(firstString(event, "type", "action")),
+		PublicUserUUID: firstString(content, "publicUserUuid"),
+		Raw:            rawPayload(b),
+	}
+	if e.UUID == "" {
+		e.UUID = firstString(event, "uuid")
+	}
+	if e.PublicUserUUID == "" {
+		e.PublicUserUUID = firstString(event, "publicUserUuid")
+	}
+	for _, value := range []interface{}{content["sessionDate"], event["timestamp"], event["date"], event["time"]} {
+		if t, ok := parseActivityTime(value); ok {
+			e.Time = t
+			break
+		}
+	}
+
+	e.Items = append(e.Items, activityItems(content["items"], "")...)
+	e.Items = append(e.Items, activityItems(content["purchase"], BringItemToPurchase)...)
+	e.Items = append(e.Items, activityItems(content["recently"], BringItemToRecently)...)
+	if len(e.Items) == 0 {
+		e.Items = activityItems(event["items"], "")
+	}
+	if len(e.Items) == 0 {
+		for _, source := range []map[string]interface{}{content, event} {
+			if name := firstString(source, "itemId", "itemName"); name != "" {
+				e.Items = []ActivityItem{{ItemID: name, Spec: firstString(source, "specification", "spec")}}
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func activityItems(value interface{}, status BringItemOperation) []ActivityItem {
+	entries, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+	items := make([]ActivityItem, 0, len(entries))
+	for _, entry := range entries {
+		m, ok := entry.(map[string]interface{})
+		if !ok {
+			if name := stringValue(entry); name != "" {
+				items = append(items, ActivityItem{ItemID: name, Status: status})
+			}
+			continue
+		}
+		name := firstString(m, "itemId", "name", "itemName")
+		if name == "" {
+			continue
+		}
+		item := ActivityItem{
+			ItemID: name,
+			Spec:   firstString(m, "specification", "spec"),
+			UUID:   firstString(m, "uuid"),
+			Status: status,
+		}
+		if item.Status == "" {
+			item.Status = BringItemOperation(firstString(m, "status"))
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func parseActivityTime(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case string:
+		for _, layout := range activityTimeLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, true
+			}
+		}
+	case float64:
+		if v > 0 {
+			return time.UnixMilli(int64(v)), true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,107 @@
+package bring
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestActivityTimelineUnmarshal(t *testing.T) {
+	payload := []byte(`{"timeline":[
+		{"type":"LIST_ITEMS_CHANGED","content":{"uuid":"event-1","publicUserUuid":"pub-1","sessionDate":"2024-01-01T12:00:00.000Z",
+			"purchase":[{"itemId":"Milk","specification":"2%","uuid":"item-1"}],
+			"recently":[{"itemId":"Bread"}]}},
+		{"type":"LIST_ITEMS_ADDED","timestamp":"2024-01-02T08:30:00Z","content":{"itemId":"Eggs"}}
+	],"totalEvents":2}`)
+
+	var resp GetActivityResponse
+	if err := json.Unmarshal(payload, &resp); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if len(resp.Timeline) != 2 || resp.TotalEvents != 2 {
+		t.Fatalf("unexpected response: %#v", resp)
+	}
+
+	first := resp.Timeline[0]
+	if first.Type != ActivityItemsChanged || first.UUID != "event-1" || first.PublicUserUUID != "pub-1" {
+		t.Fatalf("unexpected first event: %#v", first)
+	}
+	if !first.Time.Equal(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected time: %s", first.Time)
+	}
+	if len(first.Items) != 2 {
+		t.Fatalf("unexpected items: %#v", first.Items)
+	}
+	if first.Items[0] != (ActivityItem{ItemID: "Milk", Spec: "2%", UUID: "item-1", Status: BringItemToPurchase}) {
+		t.Fatalf("unexpected purchase item: %#v", first.Items[0])
+	}
+	if first.Items[1].ItemID != "Bread" || first.Items[1].Status != BringItemToRecently {
+		t.Fatalf("unexpected recent item: %#v", first.Items[1])
+	}
+
+	second := resp.Timeline[1]
+	if second.Type != ActivityItemsAdded || second.Time.IsZero() || len(second.Items) != 1 || second.Items[0].ItemID != "Eggs" {
+		t.Fatalf("unexpected second event: %#v", second)
+	}
+}
+
+func TestActivityEventRoundTrip(t *testing.T) {
+	payload := []byte(`{"type":"LIST_ITEMS_CHANGED","content":{"uuid":"event-1","publicUserUuid":"pub-1","sessionDate":"2024-01-01T12:00:00.000Z",
+		"purchase":[{"itemId":"Milk","specification":"2%","uuid":"item-1"}],"recently":[{"itemId":"Bread"}]}}`)
+	var event ActivityEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	var decoded ActivityEvent
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal of marshalled event failed: %v", err)
+	}
+	if decoded.UUID != event.UUID || decoded.Type != event.Type || decoded.PublicUserUUID != event.PublicUserUUID || !decoded.Time.Equal(event.Time) {
+		t.Fatalf("event changed in round trip:\n%#v\n%#v", event, decoded)
+	}
+	if len(decoded.Items) != 2 || decoded.Items[0] != event.Items[0] || decoded.Items[1] != event.Items[1] {
+		t.Fatalf("items changed in round trip: %#v", decoded.Items)
+	}
+	var raw bytes.Buffer
+	if err := json.Compact(&raw, event.Raw); err != nil {
+		t.Fatalf("compact raw: %v", err)
+	}
+	if string(decoded.Raw) != raw.String() {
+		t.Fatalf("raw changed in round trip: %s", decoded.Raw)
+	}
+}
+
+func TestNotifyReactionWithActivityEvent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var payload map[string]interface{}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		if payload["receiverPublicUserUuid"] != "pub-1" {
+			t.Fatalf("unexpected receiver: %v", payload["receiverPublicUserUuid"])
+		}
+		reaction := payload["listActivityStreamReaction"].(map[string]interface{})
+		if reaction["moduleUuid"] != "event-1" || reaction["moduleType"] != string(ActivityItemsAdded) || reaction["reactionType"] != string(ReactionHeart) {
+			t.Fatalf("unexpected reaction: %v", reaction)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := FromToken(TokenAuthOptions{AccessToken: "access-token", UserUUID: "user-uuid", PublicUserUUID: "public-uuid", URL: server.URL})
+	event := ActivityEvent{UUID: "event-1", Type: ActivityItemsAdded, PublicUserUUID: "pub-1"}
+	if _, err := client.Notify(context.Background(), "list-1", NotifyListReaction, "", event, "", "", ReactionHeart); err != nil {
+		t.Fatalf("notify failed: %v", err)
+	}
+}
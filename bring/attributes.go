@@ -0,0 +1,74 @@
+package bring
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+const purchaseConditions = "PURCHASE_CONDITIONS"
+
+// ItemAttributes are the purchase conditions of an item on a list.
+//
+// The API sends them as [{"type":"PURCHASE_CONDITIONS","content":{...}}];
+// UnmarshalJSON also accepts the flat {"urgent":...} object that
+// ItemAttributes marshals to by default, so marshalled items round-trip.
+type ItemAttributes struct {
+	Urgent     bool `json:"urgent"`
+	Convenient bool `json:"convenient"`
+	Discounted bool `json:"discounted"`
+}
+
+func (a *ItemAttributes) UnmarshalJSON(b []byte) error {
+	type plain ItemAttributes
+	var typed []struct {
+		Type    string `json:"type"`
+		Content plain  `json:"content"`
+	}
+	if err := json.Unmarshal(b, &typed); err == nil {
+		*a = ItemAttributes{}
+		for _, attribute := range typed {
+			if attribute.Type == purchaseConditions {
+				*a = ItemAttributes(attribute.Content)
+			}
+		}
+		return nil
+	}
+	return json.Unmarshal(b, (*plain)(a))
+}
+
+// IsZero reports whether no attribute is set.
+func (a ItemAttributes) IsZero() bool {
+	return a == ItemAttributes{}
+}
+
+// String lists the set attributes, e.g. "urgent, discounted".
+func (a ItemAttributes) String() string {
+	var names []string
+	if a.Urgent {
+		names = append(names, "urgent")
+	}
+	if a.Convenient {
+		names = append(names, "convenient")
+	}
+	if a.Discounted {
+		names = append(names, "discounted")
+	}
+	return strings.Join(names, ", ")
+}
+
+// Update returns the batch change that sets the attributes of itemID to a.
+func (a ItemAttributes) Update(itemID string) BatchUpdateItem {
+	return BatchUpdateItem{ItemID: itemID, Operation: BringItemAttrUpdate, Attribute: a.attribute()}
+}
+
+// attribute returns the payload of an ATTRIBUTE_UPDATE change.
+func (a ItemAttributes) attribute() map[string]interface{} {
+	return map[string]interface{}{
+		"type": purchaseConditions,
+		"content": map[string]interface{}{
+			"urgent":     a.Urgent,
+			"convenient": a.Convenient,
+			"discounted": a.Discounted,
+		},
+	}
+}
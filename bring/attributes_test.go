@@ -0,0 +1,68 @@
+package bring
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestItemAttributesUnmarshal(t *testing.T) {
+	payload := []byte(`{"purchase":[
+		{"name":"Milk","specification":"2 L","uuid":"item-1","attributes":[{"type":"PURCHASE_CONDITIONS","content":{"urgent":true,"convenient":false,"discounted":true}}]},
+		{"name":"Bread","attributes":[]},
+		{"name":"Eggs","attributes":{"convenient":true}}
+	]}`)
+
+	var items GetItemsResponse
+	if err := json.Unmarshal(payload, &items); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if got := items.Purchase[0].Attributes; got != (ItemAttributes{Urgent: true, Discounted: true}) || got.String() != "urgent, discounted" {
+		t.Fatalf("unexpected attributes: %#v", got)
+	}
+	if !items.Purchase[1].Attributes.IsZero() {
+		t.Fatalf("expected no attributes: %#v", items.Purchase[1].Attributes)
+	}
+	if !items.Purchase[2].Attributes.Convenient {
+		t.Fatalf("expected flat attributes to be accepted: %#v", items.Purchase[2].Attributes)
+	}
+}
+
+func TestSetItemAttributesPayload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/bringlists/list-1/items" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		var payload struct {
+			Changes []struct {
+				ItemID    string `json:"itemId"`
+				Operation string `json:"operation"`
+				Attribute struct {
+					Type    string          `json:"type"`
+					Content map[string]bool `json:"content"`
+				} `json:"attribute"`
+			} `json:"changes"`
+		}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		change := payload.Changes[0]
+		if change.ItemID != "Milk" || change.Operation != string(BringItemAttrUpdate) || change.Attribute.Type != "PURCHASE_CONDITIONS" {
+			t.Fatalf("unexpected change: %+v", change)
+		}
+		if !change.Attribute.Content["urgent"] || change.Attribute.Content["convenient"] || change.Attribute.Content["discounted"] {
+			t.Fatalf("unexpected attribute content: %+v", change.Attribute.Content)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := FromToken(TokenAuthOptions{AccessToken: "access-token", UserUUID: "user-uuid", URL: server.URL})
+	if _, err := client.SetItemAttributes(context.Background(), "list-1", "Milk", ItemAttributes{Urgent: true}); err != nil {
+		t.Fatalf("SetItemAttributes returned error: %v", err)
+	}
+}
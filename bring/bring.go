@@ -7,10 +7,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -18,42 +20,75 @@ const defaultBaseURL = "https://api.getbring.com/rest/v2/"
 const bringAPIKey = "cof4Nc6D8saplXjE3h3HXqHH8m7VU2i1Gs0g85Sp"
 
 // Bring is a client for the Bring! API.
+//
+// A Bring is safe for concurrent use by multiple goroutines, including while
+// its access token is being refreshed.
 type Bring struct {
-	mail         string
-	password     string
-	url          string
+	mail      string
+	password  string
+	url       string
+	client    *http.Client
+	retry     RetryPolicy
+	logger    *slog.Logger
+	cache     fileCache
+	onRefresh func(AuthTokens)
+
+	// mu guards the authentication state below. Header maps are replaced,
+	// never modified, once published, so requests can use them unlocked.
+	mu           sync.RWMutex
 	uuid         string
 	headers      map[string]string
-	Name         string
-	PublicUUID   string
+	putHeaders   map[string]string
 	bearerToken  string
 	refreshToken string
-	putHeaders   map[string]string
-	client       *http.Client
+	publicUUID   string
+	name         string
+
+	// Deprecated: Name is not safe to read while other goroutines use the
+	// client. Use UserName instead.
+	Name string
+	// Deprecated: PublicUUID is not safe to read while other goroutines use
+	// the client. Use PublicUserUUID instead.
+	PublicUUID string
+
+	// refreshMu ensures concurrent 401s trigger a single token refresh.
+	refreshMu sync.Mutex
 }
 
 // New creates a Bring client using email/password credentials.
-func New(options BringOptions) *Bring {
-	baseURL := normalizeBaseURL(options.URL)
-	return &Bring{
-		mail:     options.Mail,
-		password: options.Password,
-		url:      baseURL,
-		uuid:     options.UUID,
+func New(options BringOptions, opts ...Option) *Bring {
+	settings := clientSettings{
 		headers: map[string]string{
 			"X-BRING-API-KEY":       bringAPIKey,
 			"X-BRING-CLIENT":        "webApp",
 			"X-BRING-CLIENT-SOURCE": "webApp",
 			"X-BRING-COUNTRY":       "DE",
 		},
-		client: &http.Client{Timeout: 30 * time.Second},
+		retry: &options.Retry,
+	}
+	for _, opt := range opts {
+		opt(&settings)
+	}
+
+	b := &Bring{
+		mail:     options.Mail,
+		password: options.Password,
+		url:      normalizeBaseURL(options.URL),
+		uuid:     options.UUID,
+		client:   settings.buildHTTPClient(),
+		retry:    *settings.retry,
+		logger:   settings.logger,
+		cache:    fileCache{dir: settings.cacheDir},
 	}
+	b.publishHeaders(settings.headers)
+	return b
 }
 
 // FromToken creates a Bring client using an existing access token.
-func FromToken(options TokenAuthOptions) *Bring {
-	bring := New(BringOptions{URL: options.URL})
-	bring.setAuthHeaders(options.UserUUID, options.AccessToken, options.PublicUserUUID)
+func FromToken(options TokenAuthOptions, opts ...Option) *Bring {
+	bring := New(BringOptions{URL: options.URL, Retry: options.Retry}, opts...)
+	bring.setAuthHeaders(options.UserUUID, options.AccessToken, options.PublicUserUUID, options.RefreshToken)
+	bring.onRefresh = options.OnTokenRefresh
 	return bring
 }
 
@@ -80,16 +115,76 @@ func (b *Bring) Login(ctx context.Context) error {
 		return fmt.Errorf("cannot login: %w", err)
 	}
 
+	b.mu.Lock()
+	b.name = data.Name
 	b.Name = data.Name
-	b.setAuthHeaders(data.UUID, data.AccessToken, data.PublicUUID)
-	b.refreshToken = data.RefreshToken
+	b.mu.Unlock()
+	b.setAuthHeaders(data.UUID, data.AccessToken, data.PublicUUID, data.RefreshToken)
+	return nil
+}
+
+// RefreshAccessToken exchanges the refresh token for a new access token.
+func (b *Bring) RefreshAccessToken(ctx context.Context) error {
+	b.refreshMu.Lock()
+	defer b.refreshMu.Unlock()
+	return b.refreshAccessToken(ctx)
+}
+
+// refreshAccessToken performs the refresh; callers must hold refreshMu.
+func (b *Bring) refreshAccessToken(ctx context.Context) error {
+	b.mu.RLock()
+	refreshToken := b.refreshToken
+	b.mu.RUnlock()
+	if refreshToken == "" {
+		return errors.New("cannot refresh access token: no refresh token")
+	}
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", refreshToken)
+
+	headers := cloneHeaders(b.authHeaders())
+	delete(headers, "Authorization")
+	headers["Content-Type"] = "application/x-www-form-urlencoded; charset=UTF-8"
+
+	resp, err := b.send(ctx, http.MethodPost, b.url+"bringauth/token", headers, []byte(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("cannot refresh access token: %w", err)
+	}
+
+	var data AuthTokenResponse
+	if err := decodeJSON(resp.body, &data); err != nil {
+		return fmt.Errorf("cannot refresh access token: %w", err)
+	}
+	if data.AccessToken == "" {
+		return errors.New("cannot refresh access token: empty access token")
+	}
+
+	if data.RefreshToken != "" {
+		refreshToken = data.RefreshToken
+	}
+	// Only the token changes: identity fields may have been updated by
+	// requests that ran during the refresh.
+	b.mu.Lock()
+	b.bearerToken = data.AccessToken
+	b.refreshToken = refreshToken
+	authHeaders := cloneHeaders(b.headers)
+	authHeaders["Authorization"] = "Bearer " + data.AccessToken
+	b.publishHeaders(authHeaders)
+	b.mu.Unlock()
+	if b.onRefresh != nil {
+		b.onRefresh(AuthTokens{
+			AccessToken:  data.AccessToken,
+			RefreshToken: refreshToken,
+			ExpiresIn:    data.ExpiresIn,
+		})
+	}
 	return nil
 }
 
 // LoadLists loads all shopping lists.
 func (b *Bring) LoadLists(ctx context.Context) (LoadListsResponse, error) {
 	var lists LoadListsResponse
-	body, _, err := b.doRequest(ctx, http.MethodGet, b.url+"bringusers/"+b.uuid+"/lists", b.headers, nil)
+	body, _, err := b.doRequest(ctx, http.MethodGet, b.url+"bringusers/"+b.userUUID()+"/lists", b.authHeaders(), nil)
 	if err != nil {
 		return lists, fmt.Errorf("cannot get lists: %w", err)
 	}
@@ -99,10 +194,83 @@ func (b *Bring) LoadLists(ctx context.Context) (LoadListsResponse, error) {
 	return lists, nil
 }
 
+// CreateList creates a shopping list and returns its UUID. An empty theme
+// uses ThemeHome.
+func (b *Bring) CreateList(ctx context.Context, name, theme string) (string, error) {
+	if theme == "" {
+		theme = ThemeHome
+	}
+	form := url.Values{}
+	form.Set("name", name)
+	form.Set("theme", theme)
+
+	body, _, err := b.doRequest(ctx, http.MethodPost, b.url+"bringusers/"+b.userUUID()+"/lists", b.formHeaders(), strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("cannot create list %q: %w", name, err)
+	}
+	var created CreateListResponse
+	if err := decodeJSON(body, &created); err != nil {
+		return "", fmt.Errorf("cannot create list %q: %w", name, err)
+	}
+	listUUID := created.BringListUUID
+	if listUUID == "" {
+		listUUID = created.ListUUID
+	}
+	if listUUID == "" {
+		return "", fmt.Errorf("cannot create list %q: response has no list UUID", name)
+	}
+	return listUUID, nil
+}
+
+// RenameList changes the name of a list.
+func (b *Bring) RenameList(ctx context.Context, listUUID, name string) (string, error) {
+	form := url.Values{}
+	form.Set("name", name)
+	body, err := b.updateList(ctx, listUUID, form)
+	if err != nil {
+		return "", fmt.Errorf("cannot rename list %s: %w", listUUID, err)
+	}
+	return body, nil
+}
+
+// SetListTheme changes the theme of a list, e.g. ThemeBBQ.
+func (b *Bring) SetListTheme(ctx context.Context, listUUID, theme string) (string, error) {
+	form := url.Values{}
+	form.Set("theme", theme)
+	body, err := b.updateList(ctx, listUUID, form)
+	if err != nil {
+		return "", fmt.Errorf("cannot set theme of list %s: %w", listUUID, err)
+	}
+	return body, nil
+}
+
+// DeleteList deletes a list including all of its items.
+func (b *Bring) DeleteList(ctx context.Context, listUUID string) (string, error) {
+	body, _, err := b.doRequest(ctx, http.MethodDelete, b.url+"bringusers/"+b.userUUID()+"/lists/"+listUUID, b.authHeaders(), nil)
+	if err != nil {
+		return "", fmt.Errorf("cannot delete list %s: %w", listUUID, err)
+	}
+	if err := decodeError(body); err != nil {
+		return "", fmt.Errorf("cannot delete list %s: %w", listUUID, err)
+	}
+	return string(body), nil
+}
+
+func (b *Bring) updateList(ctx context.Context, listUUID string, form url.Values) (string, error) {
+	body, _, err := b.doRequest(ctx, http.MethodPut, b.url+"bringusers/"+b.userUUID()+"/lists/"+listUUID, b.formHeaders(), strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	if err := decodeError(body); err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
 // GetItems gets all items from a list.
 func (b *Bring) GetItems(ctx context.Context, listUUID string) (GetItemsResponse, error) {
 	var items GetItemsResponse
-	body, _, err := b.doRequest(ctx, http.MethodGet, b.url+"bringlists/"+listUUID, b.headers, nil)
+	body, _, err := b.doRequest(ctx, http.MethodGet, b.url+"bringlists/"+listUUID, b.authHeaders(), nil)
 	if err != nil {
 		return items, fmt.Errorf("cannot get items for list %s: %w", listUUID, err)
 	}
@@ -115,7 +283,7 @@ func (b *Bring) GetItems(ctx context.Context, listUUID string) (GetItemsResponse
 // GetItemsDetails gets detailed information about items from a list.
 func (b *Bring) GetItemsDetails(ctx context.Context, listUUID string) ([]GetItemsDetailsEntry, error) {
 	var items []GetItemsDetailsEntry
-	body, _, err := b.doRequest(ctx, http.MethodGet, b.url+"bringlists/"+listUUID+"/details", b.headers, nil)
+	body, _, err := b.doRequest(ctx, http.MethodGet, b.url+"bringlists/"+listUUID+"/details", b.authHeaders(), nil)
 	if err != nil {
 		return items, fmt.Errorf("cannot get detailed items for list %s: %w", listUUID, err)
 	}
@@ -128,15 +296,20 @@ func (b *Bring) GetItemsDetails(ctx context.Context, listUUID string) ([]GetItem
 // GetUserAccount returns account information for the current user.
 func (b *Bring) GetUserAccount(ctx context.Context) (GetUserAccountResponse, error) {
 	var account GetUserAccountResponse
-	body, _, err := b.doRequest(ctx, http.MethodGet, b.url+"bringusers/"+b.uuid, b.headers, nil)
+	body, _, err := b.doRequest(ctx, http.MethodGet, b.url+"bringusers/"+b.userUUID(), b.authHeaders(), nil)
 	if err != nil {
 		return account, fmt.Errorf("cannot get user account: %w", err)
 	}
 	if err := decodeJSON(body, &account); err != nil {
 		return account, fmt.Errorf("cannot get user account: %w", err)
 	}
+	b.mu.Lock()
+	b.publicUUID = account.PublicUserUUID
 	b.PublicUUID = account.PublicUserUUID
-	b.headers["X-BRING-PUBLIC-USER-UUID"] = account.PublicUserUUID
+	headers := cloneHeaders(b.headers)
+	headers["X-BRING-PUBLIC-USER-UUID"] = account.PublicUserUUID
+	b.publishHeaders(headers)
+	b.mu.Unlock()
 	return account, nil
 }
 
@@ -149,7 +322,7 @@ func (b *Bring) SaveItem(ctx context.Context, listUUID, itemName, specification
 	form.Set("remove", "")
 	form.Set("sender", "null")
 
-	body, _, err := b.doRequest(ctx, http.MethodPut, b.url+"bringlists/"+listUUID, b.putHeaders, strings.NewReader(form.Encode()))
+	body, _, err := b.doRequest(ctx, http.MethodPut, b.url+"bringlists/"+listUUID, b.formHeaders(), strings.NewReader(form.Encode()))
 	if err != nil {
 		return "", fmt.Errorf("cannot save item %s (%s) to %s: %w", itemName, specification, listUUID, err)
 	}
@@ -171,10 +344,13 @@ func (b *Bring) UpdateItem(ctx context.Context, listUUID, itemName, specificatio
 
 // SaveItemImage saves an image for an item.
 func (b *Bring) SaveItemImage(ctx context.Context, itemUUID string, image Image) (map[string]string, error) {
+	if itemUUID == "" {
+		return nil, errors.New("cannot save item image: empty item UUID")
+	}
 	form := url.Values{}
 	form.Set("imageData", image.ImageData)
 
-	body, _, err := b.doRequest(ctx, http.MethodPut, b.url+"bringlistitemdetails/"+itemUUID+"/image", b.putHeaders, strings.NewReader(form.Encode()))
+	body, _, err := b.doRequest(ctx, http.MethodPut, b.url+"bringlistitemdetails/"+itemUUID+"/image", b.formHeaders(), strings.NewReader(form.Encode()))
 	if err != nil {
 		return nil, fmt.Errorf("cannot save item image %s: %w", itemUUID, err)
 	}
@@ -199,6 +375,17 @@ func (b *Bring) CompleteItem(ctx context.Context, listUUID, itemName, specificat
 	return resp, nil
 }
 
+// DeleteItem removes an item from a list. Unlike RemoveItem it addresses the
+// item by UUID when one is given, so items sharing a name can be told apart.
+func (b *Bring) DeleteItem(ctx context.Context, listUUID, itemName, specification, itemUUID string) (string, error) {
+	item := BatchUpdateItem{ItemID: itemName, Spec: specification, UUID: itemUUID}
+	resp, err := b.BatchUpdateItems(ctx, listUUID, []BatchUpdateItem{item}, BringItemRemove)
+	if err != nil {
+		return "", fmt.Errorf("cannot remove item %s from %s: %w", itemName, listUUID, err)
+	}
+	return resp, nil
+}
+
 // RemoveItem removes an item from a list.
 func (b *Bring) RemoveItem(ctx context.Context, listUUID, itemName string) (string, error) {
 	form := url.Values{}
@@ -208,7 +395,7 @@ func (b *Bring) RemoveItem(ctx context.Context, listUUID, itemName string) (stri
 	form.Set("remove", itemName)
 	form.Set("sender", "null")
 
-	body, _, err := b.doRequest(ctx, http.MethodPut, b.url+"bringlists/"+listUUID, b.putHeaders, strings.NewReader(form.Encode()))
+	body, _, err := b.doRequest(ctx, http.MethodPut, b.url+"bringlists/"+listUUID, b.formHeaders(), strings.NewReader(form.Encode()))
 	if err != nil {
 		return "", fmt.Errorf("cannot remove item %s from %s: %w", itemName, listUUID, err)
 	}
@@ -260,7 +447,7 @@ func (b *Bring) BatchUpdateItems(ctx context.Context, listUUID string, items []B
 		return "", err
 	}
 
-	headers := cloneHeaders(b.headers)
+	headers := cloneHeaders(b.authHeaders())
 	headers["Content-Type"] = "application/json"
 
 	body, _, err := b.doRequest(ctx, http.MethodPut, b.url+"bringlists/"+listUUID+"/items", headers, bytes.NewReader(data))
@@ -273,9 +460,41 @@ func (b *Bring) BatchUpdateItems(ctx context.Context, listUUID string, items []B
 	return string(body), nil
 }
 
+// SetItemAttributes replaces the purchase conditions of an item. Attributes
+// not set in attrs are cleared.
+func (b *Bring) SetItemAttributes(ctx context.Context, listUUID, itemName string, attrs ItemAttributes) (string, error) {
+	body, err := b.BatchUpdateItems(ctx, listUUID, []BatchUpdateItem{attrs.Update(itemName)}, BringItemAttrUpdate)
+	if err != nil {
+		return "", fmt.Errorf("cannot set attributes of %s: %w", itemName, err)
+	}
+	return body, nil
+}
+
+// AssignItem assigns an item to a list member by public user UUID. An empty
+// publicUserUUID clears the assignment.
+func (b *Bring) AssignItem(ctx context.Context, itemUUID, publicUserUUID string) (string, error) {
+	if itemUUID == "" {
+		return "", errors.New("cannot assign item: empty item UUID")
+	}
+	form := url.Values{}
+	form.Set("assignedTo", publicUserUUID)
+
+	body, _, err := b.doRequest(ctx, http.MethodPut, b.url+"bringlistitemdetails/"+itemUUID, b.formHeaders(), strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("cannot assign item %s: %w", itemUUID, err)
+	}
+	if err := decodeError(body); err != nil {
+		return "", fmt.Errorf("cannot assign item %s: %w", itemUUID, err)
+	}
+	return string(body), nil
+}
+
 // RemoveItemImage removes an image from an item.
 func (b *Bring) RemoveItemImage(ctx context.Context, itemUUID string) (string, error) {
-	body, _, err := b.doRequest(ctx, http.MethodDelete, b.url+"bringlistitemdetails/"+itemUUID+"/image", b.headers, nil)
+	if itemUUID == "" {
+		return "", errors.New("cannot remove item image: empty item UUID")
+	}
+	body, _, err := b.doRequest(ctx, http.MethodDelete, b.url+"bringlistitemdetails/"+itemUUID+"/image", b.authHeaders(), nil)
 	if err != nil {
 		return "", fmt.Errorf("cannot remove item image %s: %w", itemUUID, err)
 	}
@@ -294,7 +513,7 @@ func (b *Bring) MoveToRecentList(ctx context.Context, listUUID, itemName string)
 	form.Set("remove", "")
 	form.Set("sender", "null")
 
-	body, _, err := b.doRequest(ctx, http.MethodPut, b.url+"bringlists/"+listUUID, b.putHeaders, strings.NewReader(form.Encode()))
+	body, _, err := b.doRequest(ctx, http.MethodPut, b.url+"bringlists/"+listUUID, b.formHeaders(), strings.NewReader(form.Encode()))
 	if err != nil {
 		return "", fmt.Errorf("cannot remove item %s from %s: %w", itemName, listUUID, err)
 	}
@@ -309,7 +528,7 @@ func (b *Bring) SetListArticleLanguage(ctx context.Context, listUUID, language s
 	form := url.Values{}
 	form.Set("value", language)
 
-	body, _, err := b.doRequest(ctx, http.MethodPost, b.url+"bringusersettings/"+b.uuid+"/"+listUUID+"/listArticleLanguage", b.putHeaders, strings.NewReader(form.Encode()))
+	body, _, err := b.doRequest(ctx, http.MethodPost, b.url+"bringusersettings/"+b.userUUID()+"/"+listUUID+"/listArticleLanguage", b.formHeaders(), strings.NewReader(form.Encode()))
 	if err != nil {
 		return "", fmt.Errorf("cannot set list article language for %s: %w", listUUID, err)
 	}
@@ -322,7 +541,7 @@ func (b *Bring) SetListArticleLanguage(ctx context.Context, listUUID, language s
 // GetActivity gets activity for a shopping list.
 func (b *Bring) GetActivity(ctx context.Context, listUUID string) (GetActivityResponse, error) {
 	var activity GetActivityResponse
-	body, _, err := b.doRequest(ctx, http.MethodGet, b.url+"bringlists/"+listUUID+"/activity", b.headers, nil)
+	body, _, err := b.doRequest(ctx, http.MethodGet, b.url+"bringlists/"+listUUID+"/activity", b.authHeaders(), nil)
 	if err != nil {
 		return activity, fmt.Errorf("cannot get activity for list %s: %w", listUUID, err)
 	}
@@ -335,7 +554,7 @@ func (b *Bring) GetActivity(ctx context.Context, listUUID string) (GetActivityRe
 // GetAllUsersFromList gets all users from a list.
 func (b *Bring) GetAllUsersFromList(ctx context.Context, listUUID string) (GetAllUsersFromListResponse, error) {
 	var users GetAllUsersFromListResponse
-	body, _, err := b.doRequest(ctx, http.MethodGet, b.url+"bringlists/"+listUUID+"/users", b.headers, nil)
+	body, _, err := b.doRequest(ctx, http.MethodGet, b.url+"bringlists/"+listUUID+"/users", b.authHeaders(), nil)
 	if err != nil {
 		return users, fmt.Errorf("cannot get users from list: %w", err)
 	}
@@ -356,7 +575,7 @@ func (b *Bring) GetInspirations(ctx context.Context, filter string) (GetInspirat
 	params.Set("limit", "2147483647")
 
 	var inspirations GetInspirationsResponse
-	body, _, err := b.doRequest(ctx, http.MethodGet, b.url+"bringusers/"+b.uuid+"/inspirations?"+params.Encode(), b.headers, nil)
+	body, _, err := b.doRequest(ctx, http.MethodGet, b.url+"bringusers/"+b.userUUID()+"/inspirations?"+params.Encode(), b.authHeaders(), nil)
 	if err != nil {
 		return inspirations, fmt.Errorf("cannot get inspirations: %w", err)
 	}
@@ -367,22 +586,25 @@ func (b *Bring) GetInspirations(ctx context.Context, filter string) (GetInspirat
 }
 
 // GetInspirationDetails gets detailed recipe/inspiration content.
-func (b *Bring) GetInspirationDetails(ctx context.Context, contentUUID string) (map[string]interface{}, error) {
-	var content map[string]interface{}
-	body, _, err := b.doRequest(ctx, http.MethodGet, b.url+"bringtemplates/content/"+contentUUID, b.headers, nil)
+func (b *Bring) GetInspirationDetails(ctx context.Context, contentUUID string) (Recipe, error) {
+	var content Recipe
+	body, _, err := b.doRequest(ctx, http.MethodGet, b.url+"bringtemplates/content/"+contentUUID, b.authHeaders(), nil)
 	if err != nil {
 		return content, fmt.Errorf("cannot get inspiration details: %w", err)
 	}
 	if err := decodeJSON(body, &content); err != nil {
 		return content, fmt.Errorf("cannot get inspiration details: %w", err)
 	}
+	if content.ID == "" {
+		content.ID = contentUUID
+	}
 	return content, nil
 }
 
 // GetInspirationFilters gets available inspiration filters.
 func (b *Bring) GetInspirationFilters(ctx context.Context) (GetInspirationFiltersResponse, error) {
 	var filters GetInspirationFiltersResponse
-	body, _, err := b.doRequest(ctx, http.MethodGet, b.url+"bringusers/"+b.uuid+"/inspirationstreamfilters", b.headers, nil)
+	body, _, err := b.doRequest(ctx, http.MethodGet, b.url+"bringusers/"+b.userUUID()+"/inspirationstreamfilters", b.authHeaders(), nil)
 	if err != nil {
 		return filters, fmt.Errorf("cannot get inspiration filters: %w", err)
 	}
@@ -395,7 +617,7 @@ func (b *Bring) GetInspirationFilters(ctx context.Context) (GetInspirationFilter
 // GetUserSettings gets the user settings.
 func (b *Bring) GetUserSettings(ctx context.Context) (GetUserSettingsResponse, error) {
 	var settings GetUserSettingsResponse
-	body, _, err := b.doRequest(ctx, http.MethodGet, b.url+"bringusersettings/"+b.uuid, b.headers, nil)
+	body, _, err := b.doRequest(ctx, http.MethodGet, b.url+"bringusersettings/"+b.userUUID(), b.authHeaders(), nil)
 	if err != nil {
 		return settings, fmt.Errorf("cannot get user settings: %w", err)
 	}
@@ -407,21 +629,12 @@ func (b *Bring) GetUserSettings(ctx context.Context) (GetUserSettingsResponse, e
 
 // LoadTranslations loads translation file by locale.
 func (b *Bring) LoadTranslations(ctx context.Context, locale string) (map[string]string, error) {
-	webBase := webBaseURL()
-	resp, err := b.client.Get(webBase + "/locale/articles." + locale + ".json")
+	body, err := b.fetchStatic(ctx, webBaseURL()+"/locale/articles."+locale+".json")
 	if err != nil {
 		return nil, fmt.Errorf("cannot get translations: %w", err)
 	}
-	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("cannot get translations: %w", err)
-	}
-	if err := decodeError(body); err != nil {
-		return nil, fmt.Errorf("cannot get translations: %w", err)
-	}
 	var translations map[string]string
-	if err := json.Unmarshal(body, &translations); err != nil {
+	if err := decodeJSON(body, &translations); err != nil {
 		return nil, fmt.Errorf("cannot get translations: %w", err)
 	}
 	return translations, nil
@@ -430,20 +643,11 @@ func (b *Bring) LoadTranslations(ctx context.Context, locale string) (map[string
 // LoadCatalog loads catalog file by locale.
 func (b *Bring) LoadCatalog(ctx context.Context, locale string) (LoadCatalogResponse, error) {
 	var catalog LoadCatalogResponse
-	webBase := webBaseURL()
-	resp, err := b.client.Get(webBase + "/locale/catalog." + locale + ".json")
+	body, err := b.fetchStatic(ctx, webBaseURL()+"/locale/catalog."+locale+".json")
 	if err != nil {
 		return catalog, fmt.Errorf("cannot get catalog: %w", err)
 	}
-	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return catalog, fmt.Errorf("cannot get catalog: %w", err)
-	}
-	if err := decodeError(body); err != nil {
-		return catalog, fmt.Errorf("cannot get catalog: %w", err)
-	}
-	if err := json.Unmarshal(body, &catalog); err != nil {
+	if err := decodeJSON(body, &catalog); err != nil {
 		return catalog, fmt.Errorf("cannot get catalog: %w", err)
 	}
 	return catalog, nil
@@ -452,7 +656,7 @@ func (b *Bring) LoadCatalog(ctx context.Context, locale string) (LoadCatalogResp
 // GetPendingInvitations gets pending invitations.
 func (b *Bring) GetPendingInvitations(ctx context.Context) (GetPendingInvitationsResponse, error) {
 	var invites GetPendingInvitationsResponse
-	body, _, err := b.doRequest(ctx, http.MethodGet, b.url+"bringusers/"+b.uuid+"/invitations?status=pending", b.headers, nil)
+	body, _, err := b.doRequest(ctx, http.MethodGet, b.url+"bringusers/"+b.userUUID()+"/invitations?status=pending", b.authHeaders(), nil)
 	if err != nil {
 		return invites, fmt.Errorf("cannot get pending invitations: %w", err)
 	}
@@ -462,6 +666,51 @@ func (b *Bring) GetPendingInvitations(ctx context.Context) (GetPendingInvitation
 	return invites, nil
 }
 
+// AcceptInvitation accepts a pending invitation and joins its list.
+func (b *Bring) AcceptInvitation(ctx context.Context, invitationUUID string) (string, error) {
+	body, err := b.answerInvitation(ctx, invitationUUID, "ACCEPTED")
+	if err != nil {
+		return "", fmt.Errorf("cannot accept invitation %s: %w", invitationUUID, err)
+	}
+	return body, nil
+}
+
+// DeclineInvitation declines a pending invitation.
+func (b *Bring) DeclineInvitation(ctx context.Context, invitationUUID string) (string, error) {
+	body, err := b.answerInvitation(ctx, invitationUUID, "DECLINED")
+	if err != nil {
+		return "", fmt.Errorf("cannot decline invitation %s: %w", invitationUUID, err)
+	}
+	return body, nil
+}
+
+func (b *Bring) answerInvitation(ctx context.Context, invitationUUID, status string) (string, error) {
+	form := url.Values{}
+	form.Set("status", status)
+	body, _, err := b.doRequest(ctx, http.MethodPut, b.url+"bringusers/"+b.userUUID()+"/invitations/"+invitationUUID, b.formHeaders(), strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	if err := decodeError(body); err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// InviteToList invites the owner of email to share a list.
+func (b *Bring) InviteToList(ctx context.Context, listUUID, email string) (string, error) {
+	form := url.Values{}
+	form.Set("email", email)
+	body, _, err := b.doRequest(ctx, http.MethodPost, b.url+"bringlists/"+listUUID+"/invitations", b.formHeaders(), strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("cannot invite %s to list %s: %w", email, listUUID, err)
+	}
+	if err := decodeError(body); err != nil {
+		return "", fmt.Errorf("cannot invite %s to list %s: %w", email, listUUID, err)
+	}
+	return string(body), nil
+}
+
 // Notify sends a notification to list members.
 func (b *Bring) Notify(ctx context.Context, listUUID string, notificationType BringNotificationType, itemName string, activity interface{}, receiver string, activityType ActivityType, reaction ReactionType) (string, error) {
 	allowed := map[BringNotificationType]bool{
@@ -478,7 +727,7 @@ func (b *Bring) Notify(ctx context.Context, listUUID string, notificationType Br
 	payload := map[string]interface{}{
 		"arguments":            []string{},
 		"listNotificationType": string(notificationType),
-		"senderPublicUserUuid": b.PublicUUID,
+		"senderPublicUserUuid": b.PublicUserUUID(),
 	}
 
 	if notificationType == NotifyUrgentMessage {
@@ -510,6 +759,16 @@ func (b *Bring) Notify(ctx context.Context, listUUID string, notificationType Br
 				"moduleType":   v.Type,
 				"reactionType": string(reaction),
 			}
+		case ActivityEvent:
+			if v.PublicUserUUID == "" || v.UUID == "" || reaction == "" {
+				return "", errors.New("notificationType is LIST_ACTIVITY_STREAM_REACTION but a parameter is missing")
+			}
+			payload["receiverPublicUserUuid"] = v.PublicUserUUID
+			payload["listActivityStreamReaction"] = map[string]interface{}{
+				"moduleUuid":   v.UUID,
+				"moduleType":   string(v.Type),
+				"reactionType": string(reaction),
+			}
 		case string:
 			if v == "" || receiver == "" || activityType == "" || reaction == "" {
 				return "", errors.New("notificationType is LIST_ACTIVITY_STREAM_REACTION but a parameter is missing")
@@ -530,7 +789,7 @@ func (b *Bring) Notify(ctx context.Context, listUUID string, notificationType Br
 		return "", err
 	}
 
-	headers := cloneHeaders(b.headers)
+	headers := cloneHeaders(b.authHeaders())
 	headers["Content-Type"] = "application/json"
 
 	body, _, err := b.doRequest(ctx, http.MethodPost, b.url+"bringnotifications/lists/"+listUUID, headers, bytes.NewReader(data))
@@ -543,48 +802,166 @@ func (b *Bring) Notify(ctx context.Context, listUUID string, notificationType Br
 	return string(body), nil
 }
 
-func (b *Bring) setAuthHeaders(userUUID, accessToken, publicUUID string) {
+// UserName returns the name of the user, as reported by Login.
+func (b *Bring) UserName() string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.name
+}
+
+// PublicUserUUID returns the public UUID of the user.
+func (b *Bring) PublicUserUUID() string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.publicUUID
+}
+
+func (b *Bring) userUUID() string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.uuid
+}
+
+// authHeaders returns the current headers for JSON and GET requests. The map
+// is shared and must not be modified; clone it to add headers.
+func (b *Bring) authHeaders() map[string]string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.headers
+}
+
+// formHeaders is like authHeaders for form-encoded requests.
+func (b *Bring) formHeaders() map[string]string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.putHeaders
+}
+
+func (b *Bring) setAuthHeaders(userUUID, accessToken, publicUUID, refreshToken string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
 	b.uuid = userUUID
 	b.bearerToken = accessToken
+	b.refreshToken = refreshToken
+	b.publicUUID = publicUUID
 	b.PublicUUID = publicUUID
-	b.headers["X-BRING-USER-UUID"] = userUUID
-	b.headers["Authorization"] = "Bearer " + accessToken
+
+	headers := cloneHeaders(b.headers)
+	headers["X-BRING-USER-UUID"] = userUUID
+	headers["Authorization"] = "Bearer " + accessToken
 	if publicUUID != "" {
-		b.headers["X-BRING-PUBLIC-USER-UUID"] = publicUUID
+		headers["X-BRING-PUBLIC-USER-UUID"] = publicUUID
 	}
+	b.publishHeaders(headers)
+}
 
-	b.putHeaders = cloneHeaders(b.headers)
-	b.putHeaders["Content-Type"] = "application/x-www-form-urlencoded; charset=UTF-8"
+// publishHeaders replaces the header snapshots. Callers must hold mu or own b
+// exclusively.
+func (b *Bring) publishHeaders(headers map[string]string) {
+	putHeaders := cloneHeaders(headers)
+	putHeaders["Content-Type"] = "application/x-www-form-urlencoded; charset=UTF-8"
+	b.headers = headers
+	b.putHeaders = putHeaders
+}
+
+// response is the outcome of a single HTTP exchange.
+type response struct {
+	body   []byte
+	status int
+	header http.Header
 }
 
 func (b *Bring) doRequest(ctx context.Context, method, url string, headers map[string]string, body io.Reader) ([]byte, int, error) {
+	resp, err := b.exchange(ctx, method, url, headers, body)
+	return resp.body, resp.status, err
+}
+
+// exchange sends a request and, when the access token was rejected, refreshes
+// it once and replays the request with the new token.
+func (b *Bring) exchange(ctx context.Context, method, url string, headers map[string]string, body io.Reader) (response, error) {
+	var payload []byte
+	if body != nil {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return response{}, err
+		}
+		payload = data
+	}
+
+	resp, err := b.send(ctx, method, url, headers, payload)
+	if resp.status != http.StatusUnauthorized || headers["Authorization"] == "" {
+		return resp, err
+	}
+	authorization, refreshErr := b.refreshRejected(ctx, headers["Authorization"])
+	if refreshErr != nil {
+		return resp, err
+	}
+
+	retryHeaders := cloneHeaders(headers)
+	retryHeaders["Authorization"] = authorization
+	return b.send(ctx, method, url, retryHeaders, payload)
+}
+
+// refreshRejected returns a fresh Authorization header after the API rejected
+// the given one. When a concurrent request already refreshed the token, the
+// new token is reused instead of refreshing again.
+func (b *Bring) refreshRejected(ctx context.Context, rejected string) (string, error) {
+	b.refreshMu.Lock()
+	defer b.refreshMu.Unlock()
+	if current := b.authHeaders()["Authorization"]; current != rejected {
+		return current, nil
+	}
+	if err := b.refreshAccessToken(ctx); err != nil {
+		return "", err
+	}
+	return b.authHeaders()["Authorization"], nil
+}
+
+func (b *Bring) sendOnce(ctx context.Context, method, url string, headers map[string]string, payload []byte) (response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
 	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
-		return nil, 0, err
+		return response{}, err
 	}
 	for key, value := range headers {
 		req.Header.Set(key, value)
 	}
 
+	start := time.Now()
 	resp, err := b.client.Do(req)
 	if err != nil {
-		return nil, 0, err
+		b.logRequest(method, url, 0, start, err)
+		return response{}, err
 	}
 	defer resp.Body.Close()
+	b.logRequest(method, url, resp.StatusCode, start, nil)
 
-	data, err := io.ReadAll(resp.Body)
+	result := response{status: resp.StatusCode, header: resp.Header}
+	result.body, err = io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, resp.StatusCode, err
+		return result, err
 	}
 
-	if resp.StatusCode >= 400 {
-		if err := decodeError(data); err != nil {
-			return data, resp.StatusCode, err
-		}
-		return data, resp.StatusCode, fmt.Errorf("http %d", resp.StatusCode)
+	if apiErr := newAPIError(resp.StatusCode, url, result.body); apiErr != nil {
+		apiErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+		return result, apiErr
 	}
 
-	return data, resp.StatusCode, nil
+	return result, nil
+}
+
+func (b *Bring) logRequest(method, url string, status int, start time.Time, err error) {
+	if b.logger == nil {
+		return
+	}
+	attrs := []any{"method", method, "endpoint", endpointPath(url), "status", status, "duration", time.Since(start)}
+	if err != nil {
+		attrs = append(attrs, "error", err)
+	}
+	b.logger.Debug("bring request", attrs...)
 }
 
 func decodeJSON(body []byte, out interface{}) error {
@@ -598,20 +975,10 @@ func decodeJSON(body []byte, out interface{}) error {
 }
 
 func decodeError(body []byte) error {
-	var errResp ErrorResponse
-	if err := json.Unmarshal(body, &errResp); err != nil {
-		return nil
+	if apiErr := newAPIError(0, "", body); apiErr != nil {
+		return apiErr
 	}
-	if errResp.Error == "" {
-		return nil
-	}
-	if errResp.Message != "" {
-		return errors.New(errResp.Message)
-	}
-	if errResp.ErrorDescription != "" {
-		return errors.New(errResp.ErrorDescription)
-	}
-	return errors.New(errResp.Error)
+	return nil
 }
 
 func normalizeBaseURL(base string) string {
@@ -3,6 +3,7 @@ package bring
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -163,6 +164,34 @@ func TestRemoveItemFormBody(t *testing.T) {
 	}
 }
 
+func TestDeleteItemSendsUUID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/bringlists/list-1/items" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		var payload struct {
+			Changes []map[string]interface{} `json:"changes"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if len(payload.Changes) != 1 {
+			t.Fatalf("unexpected changes: %v", payload.Changes)
+		}
+		change := payload.Changes[0]
+		if change["uuid"] != "milk-2" || change["itemId"] != "Milk" || change["operation"] != string(BringItemRemove) {
+			t.Fatalf("unexpected change: %v", change)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := FromToken(TokenAuthOptions{AccessToken: "access-token", UserUUID: "user-uuid", URL: server.URL})
+	if _, err := client.DeleteItem(context.Background(), "list-1", "Milk", "oat", "milk-2"); err != nil {
+		t.Fatalf("delete item failed: %v", err)
+	}
+}
+
 func TestMoveToRecentFormBody(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/bringlists/list-1" {
@@ -189,6 +218,50 @@ func TestMoveToRecentFormBody(t *testing.T) {
 	}
 }
 
+func TestListLifecycleRequests(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		values, _ := url.ParseQuery(string(body))
+		calls = append(calls, r.Method+" "+r.URL.Path+" "+values.Encode())
+		if r.Method == http.MethodPost {
+			_ = json.NewEncoder(w).Encode(map[string]string{"bringListUUID": "list-new"})
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := FromToken(TokenAuthOptions{AccessToken: "access-token", UserUUID: "user-uuid", URL: server.URL})
+	ctx := context.Background()
+	listUUID, err := client.CreateList(ctx, "Party", "")
+	if err != nil {
+		t.Fatalf("CreateList returned error: %v", err)
+	}
+	if listUUID != "list-new" {
+		t.Fatalf("unexpected list UUID: %s", listUUID)
+	}
+	if _, err := client.RenameList(ctx, listUUID, "BBQ"); err != nil {
+		t.Fatalf("RenameList returned error: %v", err)
+	}
+	if _, err := client.SetListTheme(ctx, listUUID, ThemeBBQ); err != nil {
+		t.Fatalf("SetListTheme returned error: %v", err)
+	}
+	if _, err := client.DeleteList(ctx, listUUID); err != nil {
+		t.Fatalf("DeleteList returned error: %v", err)
+	}
+
+	want := []string{
+		"POST /bringusers/user-uuid/lists name=Party&theme=ch.publisheria.bring.theme.home",
+		"PUT /bringusers/user-uuid/lists/list-new name=BBQ",
+		"PUT /bringusers/user-uuid/lists/list-new theme=ch.publisheria.bring.theme.bbq",
+		"DELETE /bringusers/user-uuid/lists/list-new ",
+	}
+	if strings.Join(calls, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected calls:\n%s", strings.Join(calls, "\n"))
+	}
+}
+
 func TestBatchUpdateItemsPayload(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/bringlists/list-1/items" {
@@ -309,3 +382,121 @@ func TestUserLocaleObjectUnmarshal(t *testing.T) {
 		t.Fatalf("unexpected locale: %s", resp.UserLocale.String())
 	}
 }
+
+func TestRefreshAccessTokenOnUnauthorized(t *testing.T) {
+	refreshed := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/bringauth/token":
+			body, _ := io.ReadAll(r.Body)
+			values, _ := url.ParseQuery(string(body))
+			if values.Get("grant_type") != "refresh_token" || values.Get("refresh_token") != "refresh-token" {
+				t.Fatalf("unexpected refresh body: %s", string(body))
+			}
+			if r.Header.Get("Authorization") != "" {
+				t.Fatalf("refresh should not send the expired token")
+			}
+			refreshed = true
+			_ = json.NewEncoder(w).Encode(AuthTokenResponse{AccessToken: "new-token", RefreshToken: "new-refresh", ExpiresIn: 3600})
+		case "/bringlists/list-1":
+			if r.Header.Get("Authorization") != "Bearer new-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				_ = json.NewEncoder(w).Encode(ErrorResponse{Error: "invalid_token", Message: "token expired"})
+				return
+			}
+			body, _ := io.ReadAll(r.Body)
+			values, _ := url.ParseQuery(string(body))
+			if values.Get("purchase") != "Milk" {
+				t.Fatalf("request body not replayed: %s", string(body))
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	var tokens AuthTokens
+	client := FromToken(TokenAuthOptions{
+		AccessToken:    "old-token",
+		RefreshToken:   "refresh-token",
+		UserUUID:       "user-uuid",
+		URL:            server.URL,
+		OnTokenRefresh: func(t AuthTokens) { tokens = t },
+	})
+	if _, err := client.SaveItem(context.Background(), "list-1", "Milk", ""); err != nil {
+		t.Fatalf("save item failed: %v", err)
+	}
+	if !refreshed {
+		t.Fatalf("expected token refresh")
+	}
+	if tokens.AccessToken != "new-token" || tokens.RefreshToken != "new-refresh" || tokens.ExpiresIn != 3600 {
+		t.Fatalf("unexpected refreshed tokens: %#v", tokens)
+	}
+	if client.headers["Authorization"] != "Bearer new-token" {
+		t.Fatalf("auth header not updated: %s", client.headers["Authorization"])
+	}
+}
+
+func TestUnauthorizedWithoutRefreshToken(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(ErrorResponse{Error: "invalid_token", Message: "token expired"})
+	}))
+	defer server.Close()
+
+	client := FromToken(TokenAuthOptions{AccessToken: "access-token", UserUUID: "user-uuid", URL: server.URL})
+	if _, err := client.LoadLists(context.Background()); err == nil {
+		t.Fatalf("expected error")
+	}
+	if calls != 1 {
+		t.Fatalf("expected a single request, got %d", calls)
+	}
+	if err := client.RefreshAccessToken(context.Background()); err == nil {
+		t.Fatalf("expected refresh error without refresh token")
+	}
+}
+
+func TestAPIErrorCarriesStatusAndSentinels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/bringlists/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(ErrorResponse{Error: "not_found", Message: "List not found", ErrorCode: 404})
+		case "/bringlists/busy":
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	client := FromToken(TokenAuthOptions{AccessToken: "access-token", UserUUID: "user-uuid", URL: server.URL, Retry: RetryPolicy{MaxAttempts: 1}})
+
+	_, err := client.GetItems(context.Background(), "missing")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Code != "not_found" || apiErr.ErrorCode != 404 {
+		t.Fatalf("unexpected api error: %#v", apiErr)
+	}
+	if apiErr.Endpoint != "/bringlists/missing" || len(apiErr.Body) == 0 {
+		t.Fatalf("unexpected endpoint or body: %#v", apiErr)
+	}
+	if !errors.Is(err, ErrNotFound) || errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("unexpected sentinel match for %v", err)
+	}
+	if !strings.Contains(err.Error(), "List not found") {
+		t.Fatalf("unexpected message: %v", err)
+	}
+
+	if _, err := client.GetItems(context.Background(), "busy"); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected rate limited error, got %v", err)
+	}
+	if _, err := client.LoadLists(context.Background()); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected unauthorized error, got %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package bringtest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fault makes matching requests fail instead of reaching the fake API.
+type Fault struct {
+	// Method restricts the fault to one HTTP method; empty matches any.
+	Method string
+	// Path is matched as a prefix of the request path, e.g. "/bringlists/".
+	// Empty matches every request.
+	Path string
+	// Status is the response status. It defaults to 500.
+	Status int
+	// Body is sent as response body. It defaults to a JSON error payload.
+	Body string
+	// RetryAfter is sent as Retry-After header when positive.
+	RetryAfter time.Duration
+	// Delay holds the response back, e.g. to trigger client timeouts.
+	Delay time.Duration
+	// Drop closes the connection without a response.
+	Drop bool
+	// Times is the number of requests that fail; zero fails all of them.
+	Times int
+
+	hits int
+}
+
+// InjectFault registers a fault. Faults are checked in registration order
+// and removed once they have fired Times times.
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if fault.Status == 0 {
+		fault.Status = http.StatusInternalServerError
+	}
+	s.faults = append(s.faults, &fault)
+}
+
+// FailNext makes the next n requests to the path prefix fail with status.
+func (s *Server) FailNext(path string, status, n int) {
+	s.InjectFault(Fault{Path: path, Status: status, Times: n})
+}
+
+// ClearFaults removes all registered faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// matchFault returns the first fault matching r and consumes one of its
+// hits. It must be called with s.mu held.
+func (s *Server) matchFault(r *http.Request) *Fault {
+	for i, fault := range s.faults {
+		if fault.Method != "" && fault.Method != r.Method {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, fault.Path) {
+			continue
+		}
+		fault.hits++
+		if fault.Times > 0 && fault.hits >= fault.Times {
+			s.faults = append(s.faults[:i], s.faults[i+1:]...)
+		}
+		return fault
+	}
+	return nil
+}
+
+func (f *Fault) apply(w http.ResponseWriter) {
+	if f.Delay > 0 {
+		time.Sleep(f.Delay)
+	}
+	if f.Drop {
+		if hijacker, ok := w.(http.Hijacker); ok {
+			if conn, _, err := hijacker.Hijack(); err == nil {
+				_ = conn.Close()
+				return
+			}
+		}
+	}
+	if f.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int((f.RetryAfter+time.Second-1)/time.Second)))
+	}
+	if f.Body != "" {
+		w.WriteHeader(f.Status)
+		_, _ = w.Write([]byte(f.Body))
+		return
+	}
+	writeError(w, f.Status, strings.ToLower(strings.ReplaceAll(http.StatusText(f.Status), " ", "_")), "injected fault")
+}
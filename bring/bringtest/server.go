@@ -0,0 +1,869 @@
+// Package bringtest provides an in-process fake of the Bring! API for tests.
+//
+// The fake keeps lists, items, users, activity, inspirations, catalogs and
+// notifications in memory and implements the endpoints used by the bring
+// package, including the form PUT and JSON batch item updates. Faults can be
+// injected per endpoint to exercise error handling and retries.
+package bringtest
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/benithors/brings-cli/bring"
+)
+
+// Account is the user the fake server authenticates.
+type Account struct {
+	UUID         string
+	PublicUUID   string
+	Name         string
+	Email        string
+	Password     string
+	AccessToken  string
+	RefreshToken string
+}
+
+// Item is an item stored on a fake list.
+type Item struct {
+	UUID       string
+	ItemID     string
+	Spec       string
+	SectionID  string
+	AssignedTo string
+	ImageURL   string
+	Attributes bring.ItemAttributes
+}
+
+// Notification is a notification received by the fake server.
+type Notification struct {
+	ListUUID string
+	Type     string
+	Payload  map[string]interface{}
+}
+
+// SentInvitation is an invitation sent through the fake server.
+type SentInvitation struct {
+	ListUUID string
+	Email    string
+}
+
+type invitation struct {
+	uuid         string
+	list         *list
+	inviterName  string
+	inviterEmail string
+	status       string
+}
+
+// Request is a request received by the fake server.
+type Request struct {
+	Method string
+	Path   string
+	Body   []byte
+}
+
+type list struct {
+	uuid     string
+	name     string
+	theme    string
+	purchase []*Item
+	recently []*Item
+	members  []bring.GetAllUsersFromListEntry
+	activity []map[string]interface{}
+}
+
+// Server is a stateful fake Bring! API server.
+type Server struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	account       Account
+	lists         []*list
+	inspirations  []map[string]interface{}
+	filters       []map[string]interface{}
+	settings      bring.GetUserSettingsResponse
+	catalogs      map[string]bring.LoadCatalogResponse
+	translations  map[string]map[string]string
+	notifications []Notification
+	invitations   []*invitation
+	sent          []SentInvitation
+	requests      []Request
+	faults        []*Fault
+	nextID        int
+}
+
+// NewServer starts a fake server with a single account and no lists.
+func NewServer() *Server {
+	s := &Server{
+		account: Account{
+			UUID:         "user-uuid",
+			PublicUUID:   "public-uuid",
+			Name:         "Tester",
+			Email:        "user@example.com",
+			Password:     "secret",
+			AccessToken:  "access-token",
+			RefreshToken: "refresh-token",
+		},
+		catalogs:     map[string]bring.LoadCatalogResponse{},
+		translations: map[string]map[string]string{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Account returns the account the server authenticates.
+func (s *Server) Account() Account {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.account
+}
+
+// Client returns a client authenticated against the fake server. Retries use
+// millisecond delays; opts are applied afterwards and may override that.
+func (s *Server) Client(opts ...bring.Option) *bring.Bring {
+	account := s.Account()
+	all := append([]bring.Option{
+		bring.WithRetryPolicy(bring.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}),
+	}, opts...)
+	return bring.FromToken(bring.TokenAuthOptions{
+		AccessToken:    account.AccessToken,
+		RefreshToken:   account.RefreshToken,
+		UserUUID:       account.UUID,
+		PublicUserUUID: account.PublicUUID,
+		URL:            s.URL,
+	}, all...)
+}
+
+// ExpireAccessToken invalidates the current access token. Requests using it
+// get a 401 until the client refreshes.
+func (s *Server) ExpireAccessToken() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.account.AccessToken = s.newID("access-token")
+}
+
+// AddList creates a list and returns its UUID.
+func (s *Server) AddList(name string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l := &list{uuid: s.newID("list"), name: name, theme: bring.ThemeHome}
+	l.members = []bring.GetAllUsersFromListEntry{{PublicUUID: s.account.PublicUUID, Name: s.account.Name, Email: s.account.Email}}
+	s.lists = append(s.lists, l)
+	return l.uuid
+}
+
+// AddMember adds a user to a list.
+func (s *Server) AddMember(listUUID string, user bring.GetAllUsersFromListEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if l := s.findList(listUUID); l != nil {
+		l.members = append(l.members, user)
+	}
+}
+
+// AddItem puts an item on a list to purchase and returns its UUID.
+func (s *Server) AddItem(listUUID, itemID, spec string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l := s.findList(listUUID)
+	if l == nil {
+		return ""
+	}
+	item := &Item{UUID: s.newID("item"), ItemID: itemID, Spec: spec}
+	l.purchase = append(l.purchase, item)
+	return item.UUID
+}
+
+// AddRecent puts an item on the recently purchased section of a list.
+func (s *Server) AddRecent(listUUID, itemID, spec string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l := s.findList(listUUID)
+	if l == nil {
+		return ""
+	}
+	item := &Item{UUID: s.newID("item"), ItemID: itemID, Spec: spec}
+	l.recently = append(l.recently, item)
+	return item.UUID
+}
+
+// UpdateItem applies fn to the item with the given UUID, e.g. to assign it.
+func (s *Server) UpdateItem(listUUID, itemUUID string, fn func(*Item)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if l := s.findList(listUUID); l != nil {
+		for _, item := range append(append([]*Item{}, l.purchase...), l.recently...) {
+			if item.UUID == itemUUID {
+				fn(item)
+			}
+		}
+	}
+}
+
+// Purchase returns copies of the items to purchase on a list.
+func (s *Server) Purchase(listUUID string) []Item {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if l := s.findList(listUUID); l != nil {
+		return copyItems(l.purchase)
+	}
+	return nil
+}
+
+// Recently returns copies of the recently purchased items on a list.
+func (s *Server) Recently(listUUID string) []Item {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if l := s.findList(listUUID); l != nil {
+		return copyItems(l.recently)
+	}
+	return nil
+}
+
+// AddInspiration adds inspiration content. The content is returned verbatim
+// by the details endpoint and wrapped in {"content": ...} by the stream.
+func (s *Server) AddInspiration(content map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inspirations = append(s.inspirations, content)
+}
+
+// SetInspirationFilters sets the available inspiration filters.
+func (s *Server) SetInspirationFilters(filters []map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.filters = filters
+}
+
+// SetUserSettings sets the user settings returned by the server.
+func (s *Server) SetUserSettings(settings bring.GetUserSettingsResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.settings = settings
+}
+
+// SetCatalog sets the catalog served for a locale under /locale/.
+func (s *Server) SetCatalog(locale string, catalog bring.LoadCatalogResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.catalogs[locale] = catalog
+}
+
+// SetTranslations sets the article translations served for a locale.
+func (s *Server) SetTranslations(locale string, translations map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.translations[locale] = translations
+}
+
+// AddInvitation adds a pending invitation to a new list named listName and
+// returns the invitation UUID. Accepting it adds the list to the account.
+func (s *Server) AddInvitation(listName, inviterName, inviterEmail string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	inv := &invitation{
+		uuid:         s.newID("invitation"),
+		list:         &list{uuid: s.newID("list"), name: listName, theme: bring.ThemeHome},
+		inviterName:  inviterName,
+		inviterEmail: inviterEmail,
+		status:       "pending",
+	}
+	inv.list.members = []bring.GetAllUsersFromListEntry{{Name: inviterName, Email: inviterEmail}}
+	s.invitations = append(s.invitations, inv)
+	return inv.uuid
+}
+
+// InvitationStatus returns the status of an invitation added with
+// AddInvitation: "pending", "ACCEPTED" or "DECLINED".
+func (s *Server) InvitationStatus(invitationUUID string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, inv := range s.invitations {
+		if inv.uuid == invitationUUID {
+			return inv.status
+		}
+	}
+	return ""
+}
+
+// SentInvitations returns the invitations sent to other users so far.
+func (s *Server) SentInvitations() []SentInvitation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]SentInvitation(nil), s.sent...)
+}
+
+// Notifications returns the notifications received so far.
+func (s *Server) Notifications() []Notification {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Notification(nil), s.notifications...)
+}
+
+// Requests returns the requests received so far, including failed ones.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+func (s *Server) newID(prefix string) string {
+	s.nextID++
+	return fmt.Sprintf("%s-%d", prefix, s.nextID)
+}
+
+func (s *Server) findList(listUUID string) *list {
+	for _, l := range s.lists {
+		if l.uuid == listUUID {
+			return l
+		}
+	}
+	return nil
+}
+
+func copyItems(items []*Item) []Item {
+	out := make([]Item, 0, len(items))
+	for _, item := range items {
+		out = append(out, *item)
+	}
+	return out
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Body: body})
+	fault := s.matchFault(r)
+	s.mu.Unlock()
+
+	if fault != nil {
+		fault.apply(w)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case segments[0] == "locale":
+		s.serveLocale(w, r, segments)
+		return
+	case r.URL.Path == "/bringauth":
+		s.serveLogin(w, body)
+		return
+	case r.URL.Path == "/bringauth/token":
+		s.serveRefresh(w, body)
+		return
+	}
+
+	if r.Header.Get("Authorization") != "Bearer "+s.account.AccessToken {
+		writeError(w, http.StatusUnauthorized, "invalid_token", "JWT access token is not valid")
+		return
+	}
+
+	if !s.route(w, r, segments, body) {
+		writeError(w, http.StatusNotFound, "not_found", "Not found: "+r.URL.Path)
+	}
+}
+
+func (s *Server) route(w http.ResponseWriter, r *http.Request, segments []string, body []byte) bool {
+	get := r.Method == http.MethodGet
+	switch {
+	case len(segments) == 2 && segments[0] == "bringusers" && get:
+		s.serveAccount(w, segments[1])
+	case len(segments) == 3 && segments[0] == "bringusers" && segments[2] == "lists" && get:
+		s.serveLists(w)
+	case len(segments) == 3 && segments[0] == "bringusers" && segments[2] == "lists" && r.Method == http.MethodPost:
+		s.serveCreateList(w, body)
+	case len(segments) == 4 && segments[0] == "bringusers" && segments[2] == "lists":
+		s.serveUpdateList(w, r, segments[3], body)
+	case len(segments) == 3 && segments[0] == "bringusers" && segments[2] == "inspirations" && get:
+		s.serveInspirations(w)
+	case len(segments) == 3 && segments[0] == "bringusers" && segments[2] == "inspirationstreamfilters" && get:
+		writeJSON(w, map[string]interface{}{"filters": s.filters})
+	case len(segments) == 3 && segments[0] == "bringusers" && segments[2] == "invitations" && get:
+		s.serveInvitations(w, r.URL.Query().Get("status"))
+	case len(segments) == 4 && segments[0] == "bringusers" && segments[2] == "invitations" && r.Method == http.MethodPut:
+		s.serveAnswerInvitation(w, segments[3], body)
+	case len(segments) == 2 && segments[0] == "bringusersettings" && get:
+		writeJSON(w, s.settings)
+	case len(segments) == 3 && segments[0] == "bringtemplates" && segments[1] == "content" && get:
+		s.serveInspirationDetails(w, segments[2])
+	case len(segments) == 3 && segments[0] == "bringnotifications" && segments[1] == "lists" && r.Method == http.MethodPost:
+		s.serveNotify(w, segments[2], body)
+	case len(segments) == 3 && segments[0] == "bringlistitemdetails" && segments[2] == "image":
+		s.serveItemImage(w, r, segments[1], body)
+	case len(segments) == 2 && segments[0] == "bringlistitemdetails" && r.Method == http.MethodPut:
+		s.serveItemDetails(w, segments[1], body)
+	case len(segments) >= 2 && segments[0] == "bringlists":
+		l := s.findList(segments[1])
+		if l == nil {
+			writeError(w, http.StatusNotFound, "not_found", "List not found")
+			return true
+		}
+		return s.routeList(w, r, l, segments[2:], body)
+	default:
+		return false
+	}
+	return true
+}
+
+func (s *Server) routeList(w http.ResponseWriter, r *http.Request, l *list, rest []string, body []byte) bool {
+	switch {
+	case len(rest) == 0 && r.Method == http.MethodGet:
+		s.serveItems(w, l)
+	case len(rest) == 0 && r.Method == http.MethodPut:
+		s.serveFormUpdate(w, l, body)
+	case len(rest) == 1 && rest[0] == "items" && r.Method == http.MethodPut:
+		s.serveBatchUpdate(w, l, body)
+	case len(rest) == 1 && rest[0] == "details" && r.Method == http.MethodGet:
+		s.serveDetails(w, l)
+	case len(rest) == 1 && rest[0] == "users" && r.Method == http.MethodGet:
+		writeJSON(w, bring.GetAllUsersFromListResponse{Users: l.members})
+	case len(rest) == 1 && rest[0] == "invitations" && r.Method == http.MethodPost:
+		values, _ := url.ParseQuery(string(body))
+		if values.Get("email") == "" {
+			writeError(w, http.StatusBadRequest, "bad_request", "email missing")
+			return true
+		}
+		s.sent = append(s.sent, SentInvitation{ListUUID: l.uuid, Email: values.Get("email")})
+		w.WriteHeader(http.StatusOK)
+	case len(rest) == 1 && rest[0] == "activity" && r.Method == http.MethodGet:
+		writeJSON(w, map[string]interface{}{
+			"timeline":    l.activity,
+			"timestamp":   time.Now().UTC().Format(time.RFC3339),
+			"totalEvents": len(l.activity),
+		})
+	default:
+		return false
+	}
+	return true
+}
+
+func (s *Server) serveLogin(w http.ResponseWriter, body []byte) {
+	values, _ := url.ParseQuery(string(body))
+	if values.Get("email") != s.account.Email || values.Get("password") != s.account.Password {
+		writeError(w, http.StatusUnauthorized, "invalid_grant", "Bad credentials")
+		return
+	}
+	writeJSON(w, bring.AuthSuccessResponse{
+		Name:         s.account.Name,
+		UUID:         s.account.UUID,
+		PublicUUID:   s.account.PublicUUID,
+		AccessToken:  s.account.AccessToken,
+		RefreshToken: s.account.RefreshToken,
+	})
+}
+
+func (s *Server) serveRefresh(w http.ResponseWriter, body []byte) {
+	values, _ := url.ParseQuery(string(body))
+	if values.Get("grant_type") != "refresh_token" || values.Get("refresh_token") != s.account.RefreshToken {
+		writeError(w, http.StatusUnauthorized, "invalid_grant", "Invalid refresh token")
+		return
+	}
+	s.account.AccessToken = s.newID("access-token")
+	s.account.RefreshToken = s.newID("refresh-token")
+	writeJSON(w, bring.AuthTokenResponse{
+		AccessToken:  s.account.AccessToken,
+		RefreshToken: s.account.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    3600,
+	})
+}
+
+func (s *Server) serveAccount(w http.ResponseWriter, userUUID string) {
+	if userUUID != s.account.UUID {
+		writeError(w, http.StatusNotFound, "not_found", "User not found")
+		return
+	}
+	writeJSON(w, map[string]interface{}{
+		"userUuid":       s.account.UUID,
+		"publicUserUuid": s.account.PublicUUID,
+		"email":          s.account.Email,
+		"emailVerified":  true,
+		"name":           s.account.Name,
+		"userLocale":     map[string]string{"language": "de", "country": "DE"},
+	})
+}
+
+func (s *Server) serveLists(w http.ResponseWriter) {
+	writeJSON(w, bring.LoadListsResponse{Lists: s.listEntries()})
+}
+
+func (s *Server) serveCreateList(w http.ResponseWriter, body []byte) {
+	values, _ := url.ParseQuery(string(body))
+	if values.Get("name") == "" {
+		writeError(w, http.StatusBadRequest, "bad_request", "name missing")
+		return
+	}
+	l := &list{uuid: s.newID("list"), name: values.Get("name"), theme: values.Get("theme")}
+	l.members = []bring.GetAllUsersFromListEntry{{PublicUUID: s.account.PublicUUID, Name: s.account.Name, Email: s.account.Email}}
+	s.lists = append(s.lists, l)
+	writeJSON(w, bring.CreateListResponse{BringListUUID: l.uuid})
+}
+
+func (s *Server) serveUpdateList(w http.ResponseWriter, r *http.Request, listUUID string, body []byte) {
+	for i, l := range s.lists {
+		if l.uuid != listUUID {
+			continue
+		}
+		switch r.Method {
+		case http.MethodPut:
+			values, _ := url.ParseQuery(string(body))
+			if name := values.Get("name"); name != "" {
+				l.name = name
+			}
+			if theme := values.Get("theme"); theme != "" {
+				l.theme = theme
+			}
+		case http.MethodDelete:
+			s.lists = append(s.lists[:i], s.lists[i+1:]...)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeError(w, http.StatusNotFound, "not_found", "List not found")
+}
+
+// Lists returns the lists stored on the server.
+func (s *Server) Lists() []bring.LoadListsEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.listEntries()
+}
+
+func (s *Server) listEntries() []bring.LoadListsEntry {
+	lists := make([]bring.LoadListsEntry, 0, len(s.lists))
+	for _, l := range s.lists {
+		lists = append(lists, bring.LoadListsEntry{ListUUID: l.uuid, Name: l.name, Theme: l.theme})
+	}
+	return lists
+}
+
+func (s *Server) serveItems(w http.ResponseWriter, l *list) {
+	entries := func(items []*Item) []map[string]interface{} {
+		out := make([]map[string]interface{}, 0, len(items))
+		for _, item := range items {
+			entry := map[string]interface{}{"uuid": item.UUID, "name": item.ItemID, "specification": item.Spec}
+			if !item.Attributes.IsZero() {
+				entry["attributes"] = []interface{}{map[string]interface{}{"type": "PURCHASE_CONDITIONS", "content": item.Attributes}}
+			}
+			out = append(out, entry)
+		}
+		return out
+	}
+	writeJSON(w, map[string]interface{}{
+		"uuid":     l.uuid,
+		"status":   "REGISTERED",
+		"purchase": entries(l.purchase),
+		"recently": entries(l.recently),
+	})
+}
+
+func (s *Server) serveDetails(w http.ResponseWriter, l *list) {
+	details := []bring.GetItemsDetailsEntry{}
+	for _, item := range append(append([]*Item{}, l.purchase...), l.recently...) {
+		details = append(details, bring.GetItemsDetailsEntry{
+			UUID:          item.UUID,
+			ItemID:        item.ItemID,
+			ListUUID:      l.uuid,
+			UserSectionID: item.SectionID,
+			AssignedTo:    item.AssignedTo,
+			ImageURL:      item.ImageURL,
+		})
+	}
+	writeJSON(w, details)
+}
+
+func (s *Server) serveFormUpdate(w http.ResponseWriter, l *list, body []byte) {
+	values, _ := url.ParseQuery(string(body))
+	switch {
+	case values.Get("purchase") != "":
+		s.applyChange(l, bring.BatchUpdateItem{ItemID: values.Get("purchase"), Spec: values.Get("specification")}, bring.BringItemToPurchase)
+	case values.Get("recently") != "":
+		s.applyChange(l, bring.BatchUpdateItem{ItemID: values.Get("recently")}, bring.BringItemToRecently)
+	case values.Get("remove") != "":
+		s.applyChange(l, bring.BatchUpdateItem{ItemID: values.Get("remove")}, bring.BringItemRemove)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) serveBatchUpdate(w http.ResponseWriter, l *list, body []byte) {
+	var payload struct {
+		Changes []bring.BatchUpdateItem `json:"changes"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", err.Error())
+		return
+	}
+	for _, change := range payload.Changes {
+		s.applyChange(l, change, change.Operation)
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// applyChange mirrors how the API moves items between the purchase and
+// recently sections. Items are matched by UUID when given, else by name.
+func (s *Server) applyChange(l *list, change bring.BatchUpdateItem, op bring.BringItemOperation) {
+	item, inPurchase, index := l.take(change.UUID, change.ItemID)
+	switch op {
+	case bring.BringItemToPurchase:
+		if item == nil {
+			item = &Item{UUID: change.UUID, ItemID: change.ItemID}
+			if item.UUID == "" {
+				item.UUID = s.newID("item")
+			}
+		}
+		item.Spec = change.Spec
+		l.purchase = append(l.purchase, item)
+		s.recordActivity(l, bring.ActivityItemsAdded, "purchase", item)
+	case bring.BringItemToRecently:
+		if item == nil {
+			return
+		}
+		if change.Spec != "" {
+			item.Spec = change.Spec
+		}
+		l.recently = append([]*Item{item}, l.recently...)
+		s.recordActivity(l, bring.ActivityItemsRemoved, "recently", item)
+	case bring.BringItemRemove:
+		if item != nil {
+			s.recordActivity(l, bring.ActivityItemsRemoved, "", item)
+		}
+	case bring.BringItemAttrUpdate:
+		if item == nil {
+			return
+		}
+		if data, err := json.Marshal([]interface{}{change.Attribute}); err == nil {
+			_ = json.Unmarshal(data, &item.Attributes)
+		}
+		l.insert(item, inPurchase, index)
+		s.recordActivity(l, bring.ActivityItemsChanged, "purchase", item)
+	}
+}
+
+// take removes and returns a matching item, whether it was to purchase and
+// its former position.
+func (l *list) take(itemUUID, itemID string) (*Item, bool, int) {
+	match := func(item *Item) bool {
+		if itemUUID != "" {
+			return item.UUID == itemUUID
+		}
+		return strings.EqualFold(item.ItemID, itemID)
+	}
+	for i, item := range l.purchase {
+		if match(item) {
+			l.purchase = append(l.purchase[:i], l.purchase[i+1:]...)
+			return item, true, i
+		}
+	}
+	for i, item := range l.recently {
+		if match(item) {
+			l.recently = append(l.recently[:i], l.recently[i+1:]...)
+			return item, false, i
+		}
+	}
+	return nil, false, 0
+}
+
+// insert puts item back at index, undoing take.
+func (l *list) insert(item *Item, purchase bool, index int) {
+	section := &l.recently
+	if purchase {
+		section = &l.purchase
+	}
+	*section = append((*section)[:index], append([]*Item{item}, (*section)[index:]...)...)
+}
+
+func (s *Server) recordActivity(l *list, activityType bring.ActivityType, section string, item *Item) {
+	content := map[string]interface{}{
+		"uuid":           s.newID("activity"),
+		"publicUserUuid": s.account.PublicUUID,
+		"sessionDate":    time.Now().UTC().Format(time.RFC3339),
+	}
+	entry := map[string]interface{}{"itemId": item.ItemID, "specification": item.Spec, "uuid": item.UUID}
+	if section == "" {
+		content["items"] = []interface{}{entry}
+	} else {
+		content[section] = []interface{}{entry}
+	}
+	l.activity = append([]map[string]interface{}{{"type": string(activityType), "content": content}}, l.activity...)
+}
+
+func (s *Server) serveInvitations(w http.ResponseWriter, status string) {
+	entries := []map[string]interface{}{}
+	for _, inv := range s.invitations {
+		if status != "" && !strings.EqualFold(inv.status, status) {
+			continue
+		}
+		entries = append(entries, map[string]interface{}{
+			"invitationUuid": inv.uuid,
+			"status":         inv.status,
+			"list":           map[string]string{"listUuid": inv.list.uuid, "name": inv.list.name},
+			"inviter":        map[string]string{"name": inv.inviterName, "email": inv.inviterEmail},
+		})
+	}
+	writeJSON(w, map[string]interface{}{"invitations": entries})
+}
+
+func (s *Server) serveAnswerInvitation(w http.ResponseWriter, invitationUUID string, body []byte) {
+	values, _ := url.ParseQuery(string(body))
+	for _, inv := range s.invitations {
+		if inv.uuid != invitationUUID {
+			continue
+		}
+		if inv.status != "pending" {
+			writeError(w, http.StatusConflict, "conflict", "Invitation already answered")
+			return
+		}
+		switch values.Get("status") {
+		case "ACCEPTED":
+			inv.list.members = append(inv.list.members, bring.GetAllUsersFromListEntry{PublicUUID: s.account.PublicUUID, Name: s.account.Name, Email: s.account.Email})
+			s.lists = append(s.lists, inv.list)
+		case "DECLINED":
+		default:
+			writeError(w, http.StatusBadRequest, "bad_request", "invalid status")
+			return
+		}
+		inv.status = values.Get("status")
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeError(w, http.StatusNotFound, "not_found", "Invitation not found")
+}
+
+func (s *Server) serveInspirations(w http.ResponseWriter) {
+	entries := make([]map[string]interface{}, 0, len(s.inspirations))
+	for _, content := range s.inspirations {
+		entries = append(entries, map[string]interface{}{"content": content})
+	}
+	writeJSON(w, map[string]interface{}{"entries": entries, "count": len(entries), "total": len(entries)})
+}
+
+func (s *Server) serveInspirationDetails(w http.ResponseWriter, contentUUID string) {
+	for _, content := range s.inspirations {
+		if content["uuid"] == contentUUID || content["contentUuid"] == contentUUID {
+			writeJSON(w, content)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "not_found", "Recipe not found")
+}
+
+func (s *Server) serveNotify(w http.ResponseWriter, listUUID string, body []byte) {
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", err.Error())
+		return
+	}
+	notificationType, _ := payload["listNotificationType"].(string)
+	s.notifications = append(s.notifications, Notification{ListUUID: listUUID, Type: notificationType, Payload: payload})
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) serveItemImage(w http.ResponseWriter, r *http.Request, itemUUID string, body []byte) {
+	item := s.findItem(itemUUID)
+	if item == nil {
+		writeError(w, http.StatusNotFound, "not_found", "Item not found")
+		return
+	}
+	switch r.Method {
+	case http.MethodPut:
+		values, _ := url.ParseQuery(string(body))
+		if values.Get("imageData") == "" {
+			writeError(w, http.StatusBadRequest, "bad_request", "imageData missing")
+			return
+		}
+		item.ImageURL = s.URL + "/images/" + itemUUID + ".jpg"
+		writeJSON(w, map[string]string{"imageUrl": item.ImageURL})
+	case http.MethodDelete:
+		item.ImageURL = ""
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) serveItemDetails(w http.ResponseWriter, itemUUID string, body []byte) {
+	item := s.findItem(itemUUID)
+	if item == nil {
+		writeError(w, http.StatusNotFound, "not_found", "Item not found")
+		return
+	}
+	values, _ := url.ParseQuery(string(body))
+	if _, ok := values["assignedTo"]; !ok {
+		writeError(w, http.StatusBadRequest, "bad_request", "assignedTo missing")
+		return
+	}
+	item.AssignedTo = values.Get("assignedTo")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) findItem(itemUUID string) *Item {
+	for _, l := range s.lists {
+		for _, item := range append(append([]*Item{}, l.purchase...), l.recently...) {
+			if item.UUID == itemUUID {
+				return item
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Server) serveLocale(w http.ResponseWriter, r *http.Request, segments []string) {
+	if len(segments) != 2 {
+		writeError(w, http.StatusNotFound, "not_found", "Not found")
+		return
+	}
+	name := strings.TrimSuffix(segments[1], ".json")
+	var value interface{}
+	switch {
+	case strings.HasPrefix(name, "catalog."):
+		if catalog, ok := s.catalogs[strings.TrimPrefix(name, "catalog.")]; ok {
+			value = catalog
+		}
+	case strings.HasPrefix(name, "articles."):
+		if translations, ok := s.translations[strings.TrimPrefix(name, "articles.")]; ok {
+			value = translations
+		}
+	}
+	if value == nil {
+		writeError(w, http.StatusNotFound, "not_found", "Locale not found")
+		return
+	}
+
+	data, _ := json.Marshal(value)
+	etag := fmt.Sprintf(`"%x"`, sha256.Sum256(data))
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+func writeJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(bring.ErrorResponse{Error: code, Message: message, ErrorCode: status})
+}
@@ -0,0 +1,192 @@
+package bringtest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/benithors/brings-cli/bring"
+)
+
+func TestLoginAndLists(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	listUUID := server.AddList("Groceries")
+
+	client := bring.New(bring.BringOptions{Mail: "user@example.com", Password: "secret", URL: server.URL})
+	if err := client.Login(context.Background()); err != nil {
+		t.Fatalf("Login returned error: %v", err)
+	}
+	lists, err := client.LoadLists(context.Background())
+	if err != nil {
+		t.Fatalf("LoadLists returned error: %v", err)
+	}
+	if len(lists.Lists) != 1 || lists.Lists[0].ListUUID != listUUID || lists.Lists[0].Name != "Groceries" {
+		t.Fatalf("unexpected lists: %+v", lists.Lists)
+	}
+
+	wrong := bring.New(bring.BringOptions{Mail: "user@example.com", Password: "nope", URL: server.URL})
+	if err := wrong.Login(context.Background()); !errors.Is(err, bring.ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized for bad credentials, got %v", err)
+	}
+}
+
+func TestItemsMoveBetweenSections(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	listUUID := server.AddList("Groceries")
+	client := server.Client()
+	ctx := context.Background()
+
+	if _, err := client.SaveItem(ctx, listUUID, "Milk", "2 L"); err != nil {
+		t.Fatalf("SaveItem returned error: %v", err)
+	}
+	if _, err := client.SaveItem(ctx, listUUID, "Bread", ""); err != nil {
+		t.Fatalf("SaveItem returned error: %v", err)
+	}
+	if _, err := client.BatchUpdateItems(ctx, listUUID, []bring.BatchUpdateItem{{ItemID: "Bread"}}, bring.BringItemToRecently); err != nil {
+		t.Fatalf("BatchUpdateItems returned error: %v", err)
+	}
+
+	items, err := client.GetItems(ctx, listUUID)
+	if err != nil {
+		t.Fatalf("GetItems returned error: %v", err)
+	}
+	if len(items.Purchase) != 1 || items.Purchase[0].Name != "Milk" || items.Purchase[0].Specification != "2 L" {
+		t.Fatalf("unexpected purchase items: %+v", items.Purchase)
+	}
+	if len(items.Recently) != 1 || items.Recently[0].Name != "Bread" {
+		t.Fatalf("unexpected recent items: %+v", items.Recently)
+	}
+
+	details, err := client.GetItemsDetails(ctx, listUUID)
+	if err != nil {
+		t.Fatalf("GetItemsDetails returned error: %v", err)
+	}
+	if len(details) != 2 || details[0].UUID == "" || details[0].ListUUID != listUUID {
+		t.Fatalf("unexpected details: %+v", details)
+	}
+
+	if _, err := client.RemoveItem(ctx, listUUID, "Milk"); err != nil {
+		t.Fatalf("RemoveItem returned error: %v", err)
+	}
+	if purchase := server.Purchase(listUUID); len(purchase) != 0 {
+		t.Fatalf("expected empty purchase section, got %+v", purchase)
+	}
+
+	activity, err := client.GetActivity(ctx, listUUID)
+	if err != nil {
+		t.Fatalf("GetActivity returned error: %v", err)
+	}
+	if len(activity.Timeline) != 4 || activity.Timeline[0].Type != bring.ActivityItemsRemoved {
+		t.Fatalf("unexpected timeline: %+v", activity.Timeline)
+	}
+	if items := activity.Timeline[1].Items; len(items) != 1 || items[0].ItemID != "Bread" || items[0].Status != bring.BringItemToRecently {
+		t.Fatalf("unexpected activity items: %+v", items)
+	}
+}
+
+func TestExpiredTokenIsRefreshed(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.AddList("Groceries")
+
+	var refreshed bring.AuthTokens
+	client := bring.FromToken(bring.TokenAuthOptions{
+		AccessToken:    server.Account().AccessToken,
+		RefreshToken:   server.Account().RefreshToken,
+		UserUUID:       server.Account().UUID,
+		URL:            server.URL,
+		OnTokenRefresh: func(tokens bring.AuthTokens) { refreshed = tokens },
+	})
+	server.ExpireAccessToken()
+
+	if _, err := client.LoadLists(context.Background()); err != nil {
+		t.Fatalf("LoadLists returned error: %v", err)
+	}
+	if refreshed.AccessToken == "" || refreshed.AccessToken != server.Account().AccessToken {
+		t.Fatalf("expected refreshed token %q, got %+v", server.Account().AccessToken, refreshed)
+	}
+}
+
+func TestInjectedFaults(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	listUUID := server.AddList("Groceries")
+	client := server.Client()
+	ctx := context.Background()
+
+	server.FailNext("/bringlists/", http.StatusServiceUnavailable, 2)
+	if _, err := client.GetItems(ctx, listUUID); err != nil {
+		t.Fatalf("expected retries to recover, got %v", err)
+	}
+
+	server.InjectFault(Fault{Method: http.MethodGet, Path: "/bringusers/", Status: http.StatusTooManyRequests})
+	_, err := client.LoadLists(ctx)
+	if !errors.Is(err, bring.ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+
+	server.ClearFaults()
+	if _, err := client.LoadLists(ctx); err != nil {
+		t.Fatalf("LoadLists returned error after clearing faults: %v", err)
+	}
+
+	_, err = client.GetItems(ctx, "missing")
+	if !errors.Is(err, bring.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for unknown list, got %v", err)
+	}
+}
+
+func TestInspirationsCatalogAndNotifications(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	listUUID := server.AddList("Groceries")
+	server.AddInspiration(map[string]interface{}{
+		"uuid":   "recipe-1",
+		"type":   "TEMPLATE",
+		"title":  "Pancakes",
+		"yield":  "4",
+		"items":  []interface{}{map[string]interface{}{"itemId": "Eier", "spec": "2"}},
+		"author": "Chef",
+	})
+	var catalog bring.LoadCatalogResponse
+	catalog.Language = "de-DE"
+	server.SetCatalog("de-DE", catalog)
+	t.Setenv("BRINGS_WEB_BASE_URL", server.URL)
+
+	client := server.Client()
+	ctx := context.Background()
+
+	inspirations, err := client.GetInspirations(ctx, "mine")
+	if err != nil {
+		t.Fatalf("GetInspirations returned error: %v", err)
+	}
+	if len(inspirations.Entries) != 1 || inspirations.Entries[0].Title != "Pancakes" {
+		t.Fatalf("unexpected inspirations: %+v", inspirations.Entries)
+	}
+	recipe, err := client.GetInspirationDetails(ctx, "recipe-1")
+	if err != nil {
+		t.Fatalf("GetInspirationDetails returned error: %v", err)
+	}
+	if len(recipe.Ingredients) != 1 || recipe.Ingredients[0].ItemID != "Eier" {
+		t.Fatalf("unexpected recipe: %+v", recipe)
+	}
+
+	loaded, err := client.LoadCatalog(ctx, "de-DE")
+	if err != nil {
+		t.Fatalf("LoadCatalog returned error: %v", err)
+	}
+	if loaded.Language != "de-DE" {
+		t.Fatalf("unexpected catalog: %+v", loaded)
+	}
+
+	if _, err := client.Notify(ctx, listUUID, bring.NotifyGoingShopping, "", nil, "", "", ""); err != nil {
+		t.Fatalf("Notify returned error: %v", err)
+	}
+	notifications := server.Notifications()
+	if len(notifications) != 1 || notifications[0].ListUUID != listUUID || notifications[0].Type != string(bring.NotifyGoingShopping) {
+		t.Fatalf("unexpected notifications: %+v", notifications)
+	}
+}
@@ -0,0 +1,140 @@
+package bring
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+)
+
+// fileCache stores static web resources such as catalogs and translations
+// on disk, together with the validators needed to revalidate them.
+type fileCache struct {
+	dir string
+}
+
+type cacheMeta struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	FetchedAt    time.Time `json:"fetchedAt"`
+}
+
+func (c fileCache) paths(url string) (string, string) {
+	name := path.Base(url)
+	return filepath.Join(c.dir, name), filepath.Join(c.dir, name+".meta")
+}
+
+func (c fileCache) load(url string) ([]byte, cacheMeta, bool) {
+	if c.dir == "" {
+		return nil, cacheMeta{}, false
+	}
+	bodyPath, metaPath := c.paths(url)
+	body, err := os.ReadFile(bodyPath)
+	if err != nil {
+		return nil, cacheMeta{}, false
+	}
+	var meta cacheMeta
+	if data, err := os.ReadFile(metaPath); err == nil {
+		_ = json.Unmarshal(data, &meta)
+	}
+	if meta.URL != "" && meta.URL != url {
+		return nil, cacheMeta{}, false
+	}
+	return body, meta, true
+}
+
+func (c fileCache) store(url string, body []byte, header http.Header) error {
+	if c.dir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return err
+	}
+	bodyPath, _ := c.paths(url)
+	if err := writeFileAtomic(bodyPath, body); err != nil {
+		return err
+	}
+	return c.storeMeta(url, cacheMeta{
+		URL:          url,
+		ETag:         header.Get("ETag"),
+		LastModified: header.Get("Last-Modified"),
+	})
+}
+
+// storeMeta writes the metadata of a cached resource, fetched now. It is
+// written after the body so that a meta file never describes a partial body.
+func (c fileCache) storeMeta(url string, meta cacheMeta) error {
+	if c.dir == "" {
+		return nil
+	}
+	meta.FetchedAt = time.Now().UTC()
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	_, metaPath := c.paths(url)
+	return writeFileAtomic(metaPath, data)
+}
+
+// writeFileAtomic writes data to a temporary file next to name and renames
+// it into place, so readers see either the old or the new content.
+func writeFileAtomic(name string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0o644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+// fetchStatic downloads a static web resource. With a cache directory
+// configured it revalidates the cached copy via ETag/Last-Modified and falls
+// back to it when the server cannot be reached.
+func (b *Bring) fetchStatic(ctx context.Context, url string) ([]byte, error) {
+	cached, meta, ok := b.cache.load(url)
+
+	headers := map[string]string{}
+	if ok {
+		if meta.ETag != "" {
+			headers["If-None-Match"] = meta.ETag
+		}
+		if meta.LastModified != "" {
+			headers["If-Modified-Since"] = meta.LastModified
+		}
+	}
+
+	resp, err := b.exchange(ctx, http.MethodGet, url, headers, nil)
+	if err != nil {
+		var apiErr *APIError
+		offline := !errors.As(err, &apiErr) || apiErr.StatusCode >= 500
+		if ok && offline && ctx.Err() == nil {
+			return cached, nil
+		}
+		return nil, err
+	}
+	if resp.status == http.StatusNotModified && ok {
+		meta.URL = url
+		_ = b.cache.storeMeta(url, meta)
+		return cached, nil
+	}
+
+	// A failing cache write must not fail the request.
+	_ = b.cache.store(url, resp.body, resp.header)
+	return resp.body, nil
+}
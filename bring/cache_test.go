@@ -0,0 +1,108 @@
+package bring
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestLoadCatalogRevalidatesCache(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{"language":"de-DE","catalog":{"sections":[{"sectionId":"1","name":"Obst"}]}}`))
+	}))
+	defer server.Close()
+
+	t.Setenv("BRINGS_WEB_BASE_URL", server.URL)
+	dir := t.TempDir()
+	client := New(BringOptions{}, WithCacheDir(dir))
+	cache := fileCache{dir: dir}
+	url := server.URL + "/locale/catalog.de-DE.json"
+
+	for i := 0; i < 2; i++ {
+		catalog, err := client.LoadCatalog(context.Background(), "de-DE")
+		if err != nil {
+			t.Fatalf("load catalog %d failed: %v", i, err)
+		}
+		if catalog.Language != "de-DE" || len(catalog.Catalog.Sections) != 1 {
+			t.Fatalf("unexpected catalog %d: %#v", i, catalog)
+		}
+		if i == 0 {
+			// Age the cached copy so the 304 must refresh FetchedAt.
+			_, meta, ok := cache.load(url)
+			if !ok {
+				t.Fatalf("expected the catalog to be cached")
+			}
+			meta.FetchedAt = time.Now().Add(-time.Hour)
+			data, _ := json.Marshal(meta)
+			_, metaPath := cache.paths(url)
+			if err := os.WriteFile(metaPath, data, 0o644); err != nil {
+				t.Fatalf("write meta: %v", err)
+			}
+		}
+	}
+	if requests != 2 {
+		t.Fatalf("expected two requests, got %d", requests)
+	}
+	_, meta, _ := cache.load(url)
+	if meta.ETag != `"v1"` || time.Since(meta.FetchedAt) > time.Minute {
+		t.Fatalf("expected a 304 to refresh the meta: %#v", meta)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 2 {
+		t.Fatalf("expected only the body and meta files, got %v", entries)
+	}
+}
+
+func TestLoadTranslationsOfflineFallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"Milch":"Milk"}`))
+	}))
+
+	t.Setenv("BRINGS_WEB_BASE_URL", server.URL)
+	client := New(BringOptions{Retry: RetryPolicy{MaxAttempts: 1}}, WithCacheDir(t.TempDir()))
+	if _, err := client.LoadTranslations(context.Background(), "en-US"); err != nil {
+		t.Fatalf("initial load failed: %v", err)
+	}
+	server.Close()
+
+	translations, err := client.LoadTranslations(context.Background(), "en-US")
+	if err != nil {
+		t.Fatalf("expected cached translations, got %v", err)
+	}
+	if translations["Milch"] != "Milk" {
+		t.Fatalf("unexpected translations: %#v", translations)
+	}
+
+	uncached := New(BringOptions{Retry: RetryPolicy{MaxAttempts: 1}})
+	if _, err := uncached.LoadTranslations(context.Background(), "en-US"); err == nil {
+		t.Fatalf("expected error without cache")
+	}
+}
+
+func TestLoadCatalogHonorsContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	t.Setenv("BRINGS_WEB_BASE_URL", server.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := New(BringOptions{}).LoadCatalog(ctx, "en-US")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context cancellation, got %v", err)
+	}
+}
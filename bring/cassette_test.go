@@ -0,0 +1,118 @@
+package bring
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecorderRedactsAndReplays(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/bringauth":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"uuid":          "user-uuid",
+				"publicUuid":    "public-uuid",
+				"access_token":  "secret-access",
+				"refresh_token": "secret-refresh",
+				"email":         "jane.doe@example.org",
+			})
+		case "/bringusers/user-uuid/lists":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"lists": []map[string]string{{"listUuid": "list-1", "name": "Groceries"}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	recorder := NewRecorder(path, nil)
+	client := New(BringOptions{Mail: "jane.doe@example.org", Password: "hunter2", URL: server.URL}, WithTransport(recorder))
+	if err := client.Login(context.Background()); err != nil {
+		t.Fatalf("Login returned error: %v", err)
+	}
+	if _, err := client.LoadLists(context.Background()); err != nil {
+		t.Fatalf("LoadLists returned error: %v", err)
+	}
+	server.Close()
+	if err := recorder.Err(); err != nil {
+		t.Fatalf("recorder error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read cassette: %v", err)
+	}
+	for _, secret := range []string{"secret-access", "secret-refresh", "jane.doe", "hunter2", "Bearer secret"} {
+		if strings.Contains(string(data), secret) {
+			t.Fatalf("cassette leaks %q:\n%s", secret, data)
+		}
+	}
+
+	replayer, err := NewReplayer(path)
+	if err != nil {
+		t.Fatalf("NewReplayer returned error: %v", err)
+	}
+	replay := New(BringOptions{Mail: "jane.doe@example.org", Password: "hunter2", URL: server.URL}, WithTransport(replayer))
+	if err := replay.Login(context.Background()); err != nil {
+		t.Fatalf("replayed Login returned error: %v", err)
+	}
+	lists, err := replay.LoadLists(context.Background())
+	if err != nil {
+		t.Fatalf("replayed LoadLists returned error: %v", err)
+	}
+	if len(lists.Lists) != 1 || lists.Lists[0].Name != "Groceries" {
+		t.Fatalf("unexpected replayed lists: %+v", lists.Lists)
+	}
+
+	replay = New(BringOptions{URL: server.URL}, WithTransport(replayer), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+	if _, err := replay.LoadLists(context.Background()); err == nil || !strings.Contains(err.Error(), "no matching interaction") {
+		t.Fatalf("expected exhausted cassette error, got %v", err)
+	}
+}
+
+func TestCassetteFixtures(t *testing.T) {
+	replayer, err := NewReplayer(filepath.Join("testdata", "session.cassette.json"))
+	if err != nil {
+		t.Fatalf("NewReplayer returned error: %v", err)
+	}
+	client := FromToken(TokenAuthOptions{AccessToken: "token", UserUUID: "user-uuid"}, WithTransport(replayer))
+
+	recipe, err := client.GetInspirationDetails(context.Background(), "7f3c2b1e-recipe")
+	if err != nil {
+		t.Fatalf("GetInspirationDetails returned error: %v", err)
+	}
+	if recipe.Title != "Shakshuka" || recipe.Author != "Yotam" || recipe.Servings != 2 {
+		t.Fatalf("unexpected recipe: %#v", recipe)
+	}
+	if len(recipe.Ingredients) != 3 || recipe.Ingredients[1].Spec != "400 g" || !recipe.Ingredients[2].Stock {
+		t.Fatalf("unexpected ingredients: %#v", recipe.Ingredients)
+	}
+	if len(recipe.Steps) != 2 || recipe.Nutrition["calories"] != "320" {
+		t.Fatalf("unexpected steps or nutrition: %#v", recipe)
+	}
+
+	activity, err := client.GetActivity(context.Background(), "list-1")
+	if err != nil {
+		t.Fatalf("GetActivity returned error: %v", err)
+	}
+	if len(activity.Timeline) != 2 {
+		t.Fatalf("unexpected timeline: %#v", activity.Timeline)
+	}
+	first, second := activity.Timeline[0], activity.Timeline[1]
+	if first.Type != ActivityItemsChanged || len(first.Items) != 2 || first.Items[1].Status != BringItemToRecently {
+		t.Fatalf("unexpected first event: %#v", first)
+	}
+	if !second.Time.Equal(time.UnixMilli(1709229600000)) || second.Items[0].ItemID != "Kaffee" {
+		t.Fatalf("unexpected second event: %#v", second)
+	}
+}
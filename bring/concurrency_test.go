@@ -0,0 +1,130 @@
+package bring
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestConcurrentRequestsShareOneRefresh(t *testing.T) {
+	var refreshes int32
+	const validToken = "new-token"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/bringauth/token" {
+			atomic.AddInt32(&refreshes, 1)
+			// Keep the refresh in flight long enough for other requests to pile up.
+			time.Sleep(20 * time.Millisecond)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"access_token": validToken, "refresh_token": "refresh-2"})
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer "+validToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"error": "invalid_token"})
+			return
+		}
+		switch r.URL.Path {
+		case "/bringusers/user-uuid":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"userUuid": "user-uuid", "publicUserUuid": "public-uuid"})
+		case "/bringusers/user-uuid/lists":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"lists": []interface{}{}})
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	var saved []AuthTokens
+	client := FromToken(TokenAuthOptions{
+		AccessToken:    "expired-token",
+		RefreshToken:   "refresh-1",
+		UserUUID:       "user-uuid",
+		URL:            server.URL,
+		OnTokenRefresh: func(tokens AuthTokens) { saved = append(saved, tokens) },
+	})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 30)
+	for i := 0; i < 10; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			_, err := client.LoadLists(context.Background())
+			errs <- err
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := client.GetUserAccount(context.Background())
+			errs <- err
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := client.SaveItem(context.Background(), "list-1", "Milk", "")
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("concurrent request failed: %v", err)
+		}
+	}
+	if got := atomic.LoadInt32(&refreshes); got != 1 {
+		t.Fatalf("expected a single refresh, got %d", got)
+	}
+	if len(saved) != 1 || saved[0].RefreshToken != "refresh-2" {
+		t.Fatalf("unexpected refresh callbacks: %+v", saved)
+	}
+	if client.PublicUserUUID() != "public-uuid" {
+		t.Fatalf("unexpected public UUID: %s", client.PublicUserUUID())
+	}
+}
+
+func TestRefreshKeepsPublicUUIDSetDuringRefresh(t *testing.T) {
+	accountLoaded := make(chan struct{})
+	var publicHeader atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/bringauth/token":
+			// Answer only after GetUserAccount has stored the public UUID.
+			<-accountLoaded
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "new-token"})
+		case "/bringusers/user-uuid":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"userUuid": "user-uuid", "publicUserUuid": "public-uuid"})
+		case "/bringusers/user-uuid/lists":
+			publicHeader.Store(r.Header.Get("X-BRING-PUBLIC-USER-UUID"))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"lists": []interface{}{}})
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client := FromToken(TokenAuthOptions{AccessToken: "old-token", RefreshToken: "refresh-1", UserUUID: "user-uuid", URL: server.URL})
+	refreshed := make(chan error, 1)
+	go func() { refreshed <- client.RefreshAccessToken(context.Background()) }()
+	if _, err := client.GetUserAccount(context.Background()); err != nil {
+		t.Fatalf("GetUserAccount failed: %v", err)
+	}
+	close(accountLoaded)
+	if err := <-refreshed; err != nil {
+		t.Fatalf("RefreshAccessToken failed: %v", err)
+	}
+
+	if client.PublicUserUUID() != "public-uuid" {
+		t.Fatalf("refresh reverted the public UUID: %q", client.PublicUserUUID())
+	}
+	if _, err := client.LoadLists(context.Background()); err != nil {
+		t.Fatalf("LoadLists failed: %v", err)
+	}
+	if got := publicHeader.Load(); got != "public-uuid" {
+		t.Fatalf("expected the public UUID header to survive the refresh, got %q", got)
+	}
+}
@@ -0,0 +1,87 @@
+package bring
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Sentinel errors matched by *APIError via errors.Is.
+var (
+	ErrUnauthorized = errors.New("bring: unauthorized")
+	ErrNotFound     = errors.New("bring: not found")
+	ErrRateLimited  = errors.New("bring: rate limited")
+)
+
+// APIError describes an error response returned by the Bring! API.
+type APIError struct {
+	StatusCode int
+	// Code is the symbolic error from the payload, e.g. "invalid_grant".
+	Code string
+	// ErrorCode is the numeric "errorcode" from the payload, if any.
+	ErrorCode int
+	Message   string
+	Endpoint  string
+	Body      []byte
+	// RetryAfter is the wait requested by a Retry-After header, if any.
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	if e.Code != "" {
+		return e.Code
+	}
+	return fmt.Sprintf("http %d", e.StatusCode)
+}
+
+// Is reports whether the error matches one of the package sentinel errors.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		if e.StatusCode == http.StatusUnauthorized {
+			return true
+		}
+		return e.Code == "invalid_grant" || e.Code == "invalid_token" || e.Code == "unauthorized"
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// newAPIError builds an APIError from a response. It returns nil when the
+// response is neither an HTTP error nor carries an error payload.
+func newAPIError(statusCode int, endpoint string, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: statusCode, Endpoint: endpointPath(endpoint), Body: body}
+
+	var errResp ErrorResponse
+	if err := json.Unmarshal(body, &errResp); err == nil && errResp.Error != "" {
+		apiErr.Code = errResp.Error
+		apiErr.ErrorCode = errResp.ErrorCode
+		apiErr.Message = errResp.Message
+		if apiErr.Message == "" {
+			apiErr.Message = errResp.ErrorDescription
+		}
+		return apiErr
+	}
+
+	if statusCode >= 400 {
+		return apiErr
+	}
+	return nil
+}
+
+func endpointPath(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Path == "" {
+		return rawURL
+	}
+	return parsed.Path
+}
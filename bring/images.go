@@ -0,0 +1,126 @@
+package bring
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+)
+
+const (
+	// MaxImageSide is the longest side, in pixels, of uploaded item images.
+	// Larger pictures are scaled down before upload.
+	MaxImageSide = 1024
+	// maxImageBytes limits how much of an image file is read.
+	maxImageBytes = 20 << 20
+	// maxImagePixels limits the dimensions of images that are decoded, as a
+	// small file can declare a size that takes gigabytes to decode.
+	maxImagePixels = 50_000_000
+	imageQuality   = 85
+)
+
+// ErrUnsupportedImage is returned for images that are neither JPEG nor PNG.
+var ErrUnsupportedImage = errors.New("bring: unsupported image format, use JPEG or PNG")
+
+// NewImage reads a JPEG or PNG picture, scales it down to MaxImageSide and
+// returns it base64-encoded as JPEG, ready for SaveItemImage.
+func NewImage(r io.Reader) (Image, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxImageBytes+1))
+	if err != nil {
+		return Image{}, fmt.Errorf("cannot read image: %w", err)
+	}
+	if len(data) > maxImageBytes {
+		return Image{}, fmt.Errorf("cannot read image: larger than %d MB", maxImageBytes>>20)
+	}
+
+	var decodeConfig func(io.Reader) (image.Config, error)
+	var decode func(io.Reader) (image.Image, error)
+	switch http.DetectContentType(data) {
+	case "image/jpeg":
+		decodeConfig, decode = jpeg.DecodeConfig, jpeg.Decode
+	case "image/png":
+		decodeConfig, decode = png.DecodeConfig, png.Decode
+	default:
+		return Image{}, ErrUnsupportedImage
+	}
+	config, err := decodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return Image{}, fmt.Errorf("cannot decode image: %w", err)
+	}
+	if int64(config.Width)*int64(config.Height) > maxImagePixels {
+		return Image{}, fmt.Errorf("cannot decode image: %dx%d pixels is larger than %d megapixels", config.Width, config.Height, maxImagePixels/1_000_000)
+	}
+	src, err := decode(bytes.NewReader(data))
+	if err != nil {
+		return Image{}, fmt.Errorf("cannot decode image: %w", err)
+	}
+
+	var out bytes.Buffer
+	if err := jpeg.Encode(&out, flatten(scaleDown(src, MaxImageSide)), &jpeg.Options{Quality: imageQuality}); err != nil {
+		return Image{}, fmt.Errorf("cannot encode image: %w", err)
+	}
+	return Image{ImageData: base64.StdEncoding.EncodeToString(out.Bytes())}, nil
+}
+
+// SaveItemImageFrom uploads a JPEG or PNG picture read from r as the image of
+// an item. See NewImage for the conversion applied.
+func (b *Bring) SaveItemImageFrom(ctx context.Context, itemUUID string, r io.Reader) (map[string]string, error) {
+	img, err := NewImage(r)
+	if err != nil {
+		return nil, err
+	}
+	return b.SaveItemImage(ctx, itemUUID, img)
+}
+
+// scaleDown shrinks src so its longest side is at most maxSide, averaging
+// the source pixels covered by each destination pixel.
+func scaleDown(src image.Image, maxSide int) image.Image {
+	bounds := src.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w <= maxSide && h <= maxSide {
+		return src
+	}
+	dw, dh := maxSide, h*maxSide/w
+	if h > w {
+		dw, dh = w*maxSide/h, maxSide
+	}
+	if dw < 1 {
+		dw = 1
+	}
+	if dh < 1 {
+		dh = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		y0, y1 := bounds.Min.Y+y*h/dh, bounds.Min.Y+(y+1)*h/dh
+		for x := 0; x < dw; x++ {
+			x0, x1 := bounds.Min.X+x*w/dw, bounds.Min.X+(x+1)*w/dw
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, b, a, n = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca), n+1
+				}
+			}
+			dst.Set(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n)})
+		}
+	}
+	return dst
+}
+
+// flatten draws src onto a white background, since JPEG has no alpha.
+func flatten(src image.Image) image.Image {
+	dst := image.NewRGBA(src.Bounds())
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), src, src.Bounds().Min, draw.Over)
+	return dst
+}
@@ -0,0 +1,102 @@
+package bring
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestNewImageScalesAndEncodesJPEG(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 2048, 1024))
+	for y := 0; y < 1024; y++ {
+		for x := 0; x < 2048; x++ {
+			src.Set(x, y, color.NRGBA{R: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, src); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+
+	img, err := NewImage(&buf)
+	if err != nil {
+		t.Fatalf("NewImage returned error: %v", err)
+	}
+	data, err := base64.StdEncoding.DecodeString(img.ImageData)
+	if err != nil {
+		t.Fatalf("image data is not base64: %v", err)
+	}
+	decoded, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("image data is not JPEG: %v", err)
+	}
+	if got := decoded.Bounds(); got.Dx() != MaxImageSide || got.Dy() != MaxImageSide/2 {
+		t.Fatalf("unexpected size: %v", got)
+	}
+	if r, _, _, _ := decoded.At(10, 10).RGBA(); r>>8 < 180 {
+		t.Fatalf("unexpected color after scaling: %v", decoded.At(10, 10))
+	}
+}
+
+func TestNewImageFlattensTransparency(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, src); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	img, err := NewImage(&buf)
+	if err != nil {
+		t.Fatalf("NewImage returned error: %v", err)
+	}
+	data, _ := base64.StdEncoding.DecodeString(img.ImageData)
+	decoded, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("decode jpeg: %v", err)
+	}
+	if r, g, b, _ := decoded.At(1, 1).RGBA(); r>>8 < 240 || g>>8 < 240 || b>>8 < 240 {
+		t.Fatalf("expected white background, got %v", decoded.At(1, 1))
+	}
+}
+
+func TestNewImageRejectsOtherFormats(t *testing.T) {
+	_, err := NewImage(strings.NewReader("GIF89a not really"))
+	if !errors.Is(err, ErrUnsupportedImage) {
+		t.Fatalf("expected ErrUnsupportedImage, got %v", err)
+	}
+}
+
+func TestItemImageRequiresItemUUID(t *testing.T) {
+	client := FromToken(TokenAuthOptions{AccessToken: "access-token", UserUUID: "user-uuid", URL: "http://127.0.0.1:1"})
+	if _, err := client.SaveItemImage(context.Background(), "", Image{ImageData: "data"}); err == nil || !strings.Contains(err.Error(), "empty item UUID") {
+		t.Fatalf("expected SaveItemImage to reject an empty UUID, got %v", err)
+	}
+	if _, err := client.RemoveItemImage(context.Background(), ""); err == nil || !strings.Contains(err.Error(), "empty item UUID") {
+		t.Fatalf("expected RemoveItemImage to reject an empty UUID, got %v", err)
+	}
+}
+
+func TestNewImageRejectsHugeDimensions(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, 1, 1))); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	// Declare 100000x100000 pixels in the IHDR chunk and fix up its CRC.
+	data := buf.Bytes()
+	binary.BigEndian.PutUint32(data[16:20], 100000)
+	binary.BigEndian.PutUint32(data[20:24], 100000)
+	binary.BigEndian.PutUint32(data[29:33], crc32.ChecksumIEEE(data[12:29]))
+
+	_, err := NewImage(bytes.NewReader(data))
+	if err == nil || !strings.Contains(err.Error(), "megapixels") {
+		t.Fatalf("expected huge dimensions to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package bring
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Invitation is an invitation to join a shared list. List and inviter
+// details are read from flat fields or from nested "list"/"inviter" objects.
+type Invitation struct {
+	UUID         string `json:"uuid"`
+	ListUUID     string `json:"listUuid,omitempty"`
+	ListName     string `json:"listName,omitempty"`
+	InviterName  string `json:"inviterName,omitempty"`
+	InviterEmail string `json:"inviterEmail,omitempty"`
+	Status       string `json:"status,omitempty"`
+	// Time is when the invitation was sent, nil when the API omits it.
+	Time *time.Time `json:"time,omitempty"`
+	// Raw is the invitation as received, for fields not mapped above.
+	Raw json.RawMessage `json:"-"`
+}
+
+func (i *Invitation) UnmarshalJSON(b []byte) error {
+	var entry map[string]interface{}
+	if err := json.Unmarshal(b, &entry); err != nil {
+		return err
+	}
+
+	*i = Invitation{
+		UUID:         firstString(entry, "invitationUuid", "uuid"),
+		ListUUID:     firstString(entry, "listUuid", "bringListUUID"),
+		ListName:     firstString(entry, "listName"),
+		InviterName:  firstString(entry, "inviterName", "fromName", "senderName"),
+		InviterEmail: firstString(entry, "inviterEmail", "fromEmail", "senderEmail"),
+		Status:       firstString(entry, "status"),
+		Raw:          append(json.RawMessage(nil), b...),
+	}
+	if list, ok := entry["list"].(map[string]interface{}); ok {
+		if i.ListUUID == "" {
+			i.ListUUID = firstString(list, "listUuid", "bringListUUID", "uuid")
+		}
+		if i.ListName == "" {
+			i.ListName = firstString(list, "name", "listName")
+		}
+	}
+	if inviter, ok := entry["inviter"].(map[string]interface{}); ok {
+		if i.InviterName == "" {
+			i.InviterName = firstString(inviter, "name")
+		}
+		if i.InviterEmail == "" {
+			i.InviterEmail = firstString(inviter, "email")
+		}
+	}
+	for _, value := range []interface{}{entry["invitationDate"], entry["createdAt"], entry["timestamp"]} {
+		if t, ok := parseActivityTime(value); ok {
+			i.Time = &t
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package bring
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestInvitationUnmarshalVariants(t *testing.T) {
+	payload := []byte(`{"invitations":[
+		{"invitationUuid":"inv-1","status":"pending","invitationDate":"2024-05-01T10:00:00Z",
+			"list":{"listUuid":"list-1","name":"Flat"},"inviter":{"name":"Alex","email":"alex@example.com"}},
+		{"uuid":"inv-2","listUuid":"list-2","listName":"Office","fromName":"Sam"}
+	]}`)
+
+	var resp GetPendingInvitationsResponse
+	if err := json.Unmarshal(payload, &resp); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if len(resp.Invitations) != 2 {
+		t.Fatalf("unexpected invitations: %#v", resp.Invitations)
+	}
+	first := resp.Invitations[0]
+	if first.UUID != "inv-1" || first.ListUUID != "list-1" || first.ListName != "Flat" ||
+		first.InviterName != "Alex" || first.InviterEmail != "alex@example.com" || first.Time == nil || first.Time.IsZero() {
+		t.Fatalf("unexpected first invitation: %#v", first)
+	}
+	second := resp.Invitations[1]
+	if second.UUID != "inv-2" || second.ListUUID != "list-2" || second.ListName != "Office" || second.InviterName != "Sam" {
+		t.Fatalf("unexpected second invitation: %#v", second)
+	}
+	if second.Time != nil {
+		t.Fatalf("expected no time without a timestamp: %v", second.Time)
+	}
+	data, err := json.Marshal(second)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	if got := string(data); strings.Contains(got, `"time"`) || strings.Contains(got, `"raw"`) {
+		t.Fatalf("expected time and raw to be left out: %s", got)
+	}
+}
+
+func TestInvitationRequests(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		values, _ := url.ParseQuery(string(body))
+		calls = append(calls, r.Method+" "+r.URL.Path+" "+values.Encode())
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := FromToken(TokenAuthOptions{AccessToken: "access-token", UserUUID: "user-uuid", URL: server.URL})
+	ctx := context.Background()
+	if _, err := client.AcceptInvitation(ctx, "inv-1"); err != nil {
+		t.Fatalf("AcceptInvitation returned error: %v", err)
+	}
+	if _, err := client.DeclineInvitation(ctx, "inv-2"); err != nil {
+		t.Fatalf("DeclineInvitation returned error: %v", err)
+	}
+	if _, err := client.InviteToList(ctx, "list-1", "flatmate@example.com"); err != nil {
+		t.Fatalf("InviteToList returned error: %v", err)
+	}
+
+	want := []string{
+		"PUT /bringusers/user-uuid/invitations/inv-1 status=ACCEPTED",
+		"PUT /bringusers/user-uuid/invitations/inv-2 status=DECLINED",
+		"POST /bringlists/list-1/invitations email=flatmate%40example.com",
+	}
+	if len(calls) != len(want) {
+		t.Fatalf("unexpected calls: %v", calls)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Fatalf("call %d: got %q, want %q", i, calls[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package bring
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ListItem is an item of a list joined with its details.
+type ListItem struct {
+	UUID   string `json:"uuid,omitempty"`
+	ItemID string `json:"itemId"`
+	Spec   string `json:"spec,omitempty"`
+	// Status is BringItemToPurchase or BringItemToRecently.
+	Status     BringItemOperation `json:"status"`
+	Attributes ItemAttributes     `json:"attributes"`
+	// AssignedTo is the public UUID of the user the item is assigned to.
+	AssignedTo string `json:"assignedTo,omitempty"`
+	SectionID  string `json:"sectionId,omitempty"`
+	IconItemID string `json:"iconItemId,omitempty"`
+	ImageURL   string `json:"imageUrl,omitempty"`
+}
+
+// GetListItems returns the items of a list, those to purchase first, joined
+// with the assignee, section and image from GetItemsDetails.
+func (b *Bring) GetListItems(ctx context.Context, listUUID string) ([]ListItem, error) {
+	items, err := b.GetItems(ctx, listUUID)
+	if err != nil {
+		return nil, err
+	}
+	details, err := b.GetItemsDetails(ctx, listUUID)
+	if err != nil {
+		return nil, err
+	}
+	return joinItemDetails(items, details), nil
+}
+
+// joinItemDetails matches details to items by UUID and otherwise by item ID.
+// Each detail entry is used at most once, so duplicate names stay distinct.
+func joinItemDetails(items GetItemsResponse, details []GetItemsDetailsEntry) []ListItem {
+	used := make([]bool, len(details))
+	find := func(entry GetItemsResponseEntry) *GetItemsDetailsEntry {
+		if entry.UUID != "" {
+			for i := range details {
+				if !used[i] && details[i].UUID == entry.UUID {
+					used[i] = true
+					return &details[i]
+				}
+			}
+		}
+		for i := range details {
+			if !used[i] && (details[i].UUID == "" || entry.UUID == "") && strings.EqualFold(details[i].ItemID, entry.Name) {
+				used[i] = true
+				return &details[i]
+			}
+		}
+		return nil
+	}
+
+	result := make([]ListItem, 0, len(items.Purchase)+len(items.Recently))
+	for _, section := range []struct {
+		entries []GetItemsResponseEntry
+		status  BringItemOperation
+	}{
+		{items.Purchase, BringItemToPurchase},
+		{items.Recently, BringItemToRecently},
+	} {
+		for _, entry := range section.entries {
+			item := ListItem{
+				UUID:       entry.UUID,
+				ItemID:     entry.Name,
+				Spec:       entry.Specification,
+				Status:     section.status,
+				Attributes: entry.Attributes,
+			}
+			if detail := find(entry); detail != nil {
+				if item.UUID == "" {
+					item.UUID = detail.UUID
+				}
+				item.AssignedTo = detail.AssignedTo
+				item.SectionID = detail.UserSectionID
+				item.IconItemID = detail.UserIconItemID
+				item.ImageURL = detail.ImageURL
+			}
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// String returns the item name followed by its specification, if any.
+func (i ListItem) String() string {
+	if i.Spec == "" {
+		return i.ItemID
+	}
+	return fmt.Sprintf("%s (%s)", i.ItemID, i.Spec)
+}
+
+// ReplaceItem replaces item with an item called newName in a single batch
+// update, carrying over its attributes. The API cannot rename items, so the
+// new item gets its own UUID and loses assignee and image.
+func (b *Bring) ReplaceItem(ctx context.Context, listUUID string, item ListItem, newName, specification string) (string, error) {
+	changes := []BatchUpdateItem{
+		{ItemID: item.ItemID, Spec: item.Spec, UUID: item.UUID, Operation: BringItemRemove},
+		{ItemID: newName, Spec: specification, Operation: BringItemToPurchase},
+	}
+	if !item.Attributes.IsZero() {
+		changes = append(changes, item.Attributes.Update(newName))
+	}
+	resp, err := b.BatchUpdateItems(ctx, listUUID, changes, BringItemToPurchase)
+	if err != nil {
+		return "", fmt.Errorf("cannot replace item %s with %s in %s: %w", item.ItemID, newName, listUUID, err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,132 @@
+package bring
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestGetListItemsJoinsDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/bringlists/list-1":
+			_, _ = w.Write([]byte(`{"purchase":[
+				{"name":"Milk","specification":"2 L","uuid":"milk-1"},
+				{"name":"Apples","specification":"red"},
+				{"name":"Apples","specification":"green"}
+			],"recently":[{"name":"Bread"}]}`))
+		case "/bringlists/list-1/details":
+			_, _ = w.Write([]byte(`[
+				{"uuid":"milk-1","itemId":"Milk","assignedTo":"public-2","userSectionId":"Dairy","imageUrl":"https://example.com/milk.jpg"},
+				{"uuid":"apples-1","itemId":"Apples","userSectionId":"Fruit"},
+				{"uuid":"apples-2","itemId":"Apples","userSectionId":"Fruit","userIconItemId":"Apfel"}
+			]`))
+		default:
+			t.Fatalf("unexpected request: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := FromToken(TokenAuthOptions{AccessToken: "access-token", UserUUID: "user-uuid", URL: server.URL})
+	items, err := client.GetListItems(context.Background(), "list-1")
+	if err != nil {
+		t.Fatalf("GetListItems failed: %v", err)
+	}
+	if len(items) != 4 {
+		t.Fatalf("expected 4 items, got %+v", items)
+	}
+
+	milk := items[0]
+	if milk.Status != BringItemToPurchase || milk.AssignedTo != "public-2" || milk.SectionID != "Dairy" || milk.ImageURL == "" {
+		t.Fatalf("unexpected milk: %+v", milk)
+	}
+	if milk.String() != "Milk (2 L)" {
+		t.Fatalf("unexpected string: %s", milk.String())
+	}
+	if items[1].UUID != "apples-1" || items[2].UUID != "apples-2" || items[2].IconItemID != "Apfel" {
+		t.Fatalf("expected duplicate names to get distinct details: %+v", items[1:3])
+	}
+	if bread := items[3]; bread.Status != BringItemToRecently || bread.UUID != "" || bread.SectionID != "" {
+		t.Fatalf("unexpected bread: %+v", bread)
+	}
+}
+
+func TestAssignItem(t *testing.T) {
+	var forms []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/bringlistitemdetails/item-1" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("parse form: %v", err)
+		}
+		forms = append(forms, r.PostForm)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := FromToken(TokenAuthOptions{AccessToken: "access-token", UserUUID: "user-uuid", URL: server.URL})
+	if _, err := client.AssignItem(context.Background(), "item-1", "public-2"); err != nil {
+		t.Fatalf("AssignItem failed: %v", err)
+	}
+	if _, err := client.AssignItem(context.Background(), "item-1", ""); err != nil {
+		t.Fatalf("clearing assignment failed: %v", err)
+	}
+	if len(forms) != 2 || forms[0].Get("assignedTo") != "public-2" {
+		t.Fatalf("unexpected forms: %v", forms)
+	}
+	if value, ok := forms[1]["assignedTo"]; !ok || value[0] != "" {
+		t.Fatalf("expected an empty assignedTo to clear: %v", forms[1])
+	}
+}
+
+func TestAssignItemRequiresItemUUID(t *testing.T) {
+	client := FromToken(TokenAuthOptions{AccessToken: "access-token", UserUUID: "user-uuid", URL: "http://127.0.0.1:1"})
+	if _, err := client.AssignItem(context.Background(), "", "public-2"); err == nil || !strings.Contains(err.Error(), "empty item UUID") {
+		t.Fatalf("expected AssignItem to reject an empty UUID, got %v", err)
+	}
+}
+
+func TestReplaceItemSendsOneBatch(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/bringlists/list-1/items" {
+			t.Fatalf("unexpected request: %s", r.URL.Path)
+		}
+		var payload struct {
+			Changes []BatchUpdateItem `json:"changes"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		changes := payload.Changes
+		if len(changes) != 3 {
+			t.Fatalf("unexpected changes: %+v", changes)
+		}
+		if changes[0].UUID != "milk-1" || changes[0].Operation != BringItemRemove {
+			t.Fatalf("expected the old item to be removed by UUID: %+v", changes[0])
+		}
+		if changes[1].ItemID != "Oat milk" || changes[1].Spec != "1 L" || changes[1].Operation != BringItemToPurchase {
+			t.Fatalf("unexpected new item: %+v", changes[1])
+		}
+		if changes[2].ItemID != "Oat milk" || changes[2].Operation != BringItemAttrUpdate {
+			t.Fatalf("expected attributes to be carried over: %+v", changes[2])
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := FromToken(TokenAuthOptions{AccessToken: "access-token", UserUUID: "user-uuid", URL: server.URL})
+	item := ListItem{UUID: "milk-1", ItemID: "Milk", Spec: "1 L", Attributes: ItemAttributes{Urgent: true}}
+	if _, err := client.ReplaceItem(context.Background(), "list-1", item, "Oat milk", "1 L"); err != nil {
+		t.Fatalf("ReplaceItem failed: %v", err)
+	}
+	if requests != 1 {
+		t.Fatalf("expected a single request, got %d", requests)
+	}
+}
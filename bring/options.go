@@ -0,0 +1,108 @@
+package bring
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+const defaultTimeout = 30 * time.Second
+
+// Option customizes a Bring client created by New or FromToken.
+type Option func(*clientSettings)
+
+type clientSettings struct {
+	httpClient *http.Client
+	transport  http.RoundTripper
+	timeout    time.Duration
+	headers    map[string]string
+	logger     *slog.Logger
+	retry      *RetryPolicy
+	cacheDir   string
+}
+
+// WithHTTPClient uses the given HTTP client instead of the default one.
+// The client is copied, so later options never modify it.
+func WithHTTPClient(client *http.Client) Option {
+	return func(s *clientSettings) {
+		s.httpClient = client
+	}
+}
+
+// WithTransport sets the round tripper used for requests, e.g. for proxying.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(s *clientSettings) {
+		s.transport = transport
+	}
+}
+
+// WithTimeout sets the overall timeout of a single HTTP attempt.
+func WithTimeout(timeout time.Duration) Option {
+	return func(s *clientSettings) {
+		s.timeout = timeout
+	}
+}
+
+// WithCountry sets the X-BRING-COUNTRY header, e.g. "CH" or "AT".
+func WithCountry(country string) Option {
+	return WithHeader("X-BRING-COUNTRY", country)
+}
+
+// WithClientHeaders sets the X-BRING-CLIENT and X-BRING-CLIENT-SOURCE headers.
+func WithClientHeaders(client, source string) Option {
+	return func(s *clientSettings) {
+		s.headers["X-BRING-CLIENT"] = client
+		s.headers["X-BRING-CLIENT-SOURCE"] = source
+	}
+}
+
+// WithUserAgent sets the User-Agent header.
+func WithUserAgent(userAgent string) Option {
+	return WithHeader("User-Agent", userAgent)
+}
+
+// WithHeader sets an additional header sent with every request.
+func WithHeader(key, value string) Option {
+	return func(s *clientSettings) {
+		s.headers[key] = value
+	}
+}
+
+// WithLogger logs each HTTP attempt at debug level. Headers and bodies are
+// never logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *clientSettings) {
+		s.logger = logger
+	}
+}
+
+// WithRetryPolicy overrides the retry policy from the options struct.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(s *clientSettings) {
+		s.retry = &policy
+	}
+}
+
+// WithCacheDir caches catalog and translation files in dir. Cached copies
+// are revalidated with ETag/Last-Modified and used when the server is
+// unreachable.
+func WithCacheDir(dir string) Option {
+	return func(s *clientSettings) {
+		s.cacheDir = dir
+	}
+}
+
+func (s clientSettings) buildHTTPClient() *http.Client {
+	client := &http.Client{Timeout: defaultTimeout}
+	if s.httpClient != nil {
+		copied := *s.httpClient
+		client = &copied
+	}
+	if s.transport != nil {
+		client.Transport = s.transport
+	}
+	if s.timeout > 0 {
+		client.Timeout = s.timeout
+	}
+	return client
+}
@@ -0,0 +1,75 @@
+package bring
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type recordingTransport struct {
+	requests int
+}
+
+func (r *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.requests++
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestOptionsCustomizeHeadersAndTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-BRING-COUNTRY") != "CH" {
+			t.Fatalf("unexpected country: %s", r.Header.Get("X-BRING-COUNTRY"))
+		}
+		if r.Header.Get("X-BRING-CLIENT") != "android" || r.Header.Get("X-BRING-CLIENT-SOURCE") != "homeAutomation" {
+			t.Fatalf("unexpected client headers: %v", r.Header)
+		}
+		if r.Header.Get("User-Agent") != "kitchen/1.0" {
+			t.Fatalf("unexpected user agent: %s", r.Header.Get("User-Agent"))
+		}
+		if r.Header.Get("Authorization") != "Bearer access-token" {
+			t.Fatalf("missing authorization header")
+		}
+		_, _ = w.Write([]byte(`{"lists":[]}`))
+	}))
+	defer server.Close()
+
+	transport := &recordingTransport{}
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	client := FromToken(TokenAuthOptions{AccessToken: "access-token", UserUUID: "user-uuid", URL: server.URL},
+		WithCountry("CH"),
+		WithClientHeaders("android", "homeAutomation"),
+		WithUserAgent("kitchen/1.0"),
+		WithTransport(transport),
+		WithLogger(logger),
+	)
+	if _, err := client.LoadLists(context.Background()); err != nil {
+		t.Fatalf("load lists failed: %v", err)
+	}
+	if transport.requests != 1 {
+		t.Fatalf("expected custom transport to be used, got %d requests", transport.requests)
+	}
+	if !strings.Contains(logs.String(), "/bringusers/user-uuid/lists") || strings.Contains(logs.String(), "access-token") {
+		t.Fatalf("unexpected log output: %s", logs.String())
+	}
+}
+
+func TestWithHTTPClientIsNotModified(t *testing.T) {
+	custom := &http.Client{Timeout: time.Minute}
+	client := New(BringOptions{}, WithHTTPClient(custom), WithTimeout(5*time.Second))
+	if client.client == custom {
+		t.Fatalf("expected the HTTP client to be copied")
+	}
+	if client.client.Timeout != 5*time.Second || custom.Timeout != time.Minute {
+		t.Fatalf("unexpected timeouts: %s / %s", client.client.Timeout, custom.Timeout)
+	}
+	if New(BringOptions{}).client.Timeout != defaultTimeout {
+		t.Fatalf("expected default timeout")
+	}
+}
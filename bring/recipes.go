@@ -0,0 +1,278 @@
+package bring
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// Inspiration is an entry of the inspirations stream. The API wraps most
+// entries in a "content" object; both shapes are accepted, as is the form
+// Inspiration marshals to.
+type Inspiration struct {
+	ID         string          `json:"id,omitempty"`
+	Type       string          `json:"type,omitempty"`
+	Title      string          `json:"title,omitempty"`
+	Author     string          `json:"author,omitempty"`
+	LikeCount  int             `json:"likeCount,omitempty"`
+	ImageURL   string          `json:"imageUrl,omitempty"`
+	LinkOutURL string          `json:"linkOutUrl,omitempty"`
+	Tags       []string        `json:"tags,omitempty"`
+	Raw        json.RawMessage `json:"raw,omitempty"`
+}
+
+func (i *Inspiration) UnmarshalJSON(b []byte) error {
+	var entry map[string]interface{}
+	if err := json.Unmarshal(b, &entry); err != nil {
+		return err
+	}
+	content, ok := entry["content"].(map[string]interface{})
+	if !ok || len(content) == 0 {
+		content = entry
+	}
+
+	*i = Inspiration{
+		ID:         firstString(content, "contentUuid", "uuid", "id"),
+		Type:       stringValue(content["type"]),
+		Title:      firstString(content, "title", "name", "campaign"),
+		Author:     firstString(content, "author", "attribution"),
+		LikeCount:  intValue(content["likeCount"]),
+		ImageURL:   imageURLFrom(content),
+		LinkOutURL: stringValue(content["linkOutUrl"]),
+		Tags:       stringSlice(content["tags"]),
+		Raw:        rawPayload(b),
+	}
+	if i.ID == "" {
+		i.ID = stringValue(entry["uuid"])
+	}
+	return nil
+}
+
+// Recipe is the detailed content of an inspiration.
+type Recipe struct {
+	ID         string   `json:"id,omitempty"`
+	Type       string   `json:"type,omitempty"`
+	Title      string   `json:"title,omitempty"`
+	Author     string   `json:"author,omitempty"`
+	LikeCount  int      `json:"likeCount,omitempty"`
+	ImageURL   string   `json:"imageUrl,omitempty"`
+	LinkOutURL string   `json:"linkOutUrl,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+	// Servings is the number of servings the ingredient amounts are for,
+	// taken from "yield", "baseQuantity" or "servings".
+	Servings    int                `json:"servings,omitempty"`
+	Ingredients []RecipeIngredient `json:"ingredients,omitempty"`
+	Steps       []RecipeStep       `json:"steps,omitempty"`
+	Nutrition   Nutrition          `json:"nutrition,omitempty"`
+	Raw         json.RawMessage    `json:"raw,omitempty"`
+}
+
+// RecipeIngredient is a single ingredient of a recipe.
+type RecipeIngredient struct {
+	ItemID string `json:"itemId,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Spec   string `json:"spec,omitempty"`
+	// Stock marks pantry staples such as salt or oil.
+	Stock bool `json:"stock,omitempty"`
+}
+
+// ItemName returns the name to put on a shopping list.
+func (r RecipeIngredient) ItemName() string {
+	if r.ItemID != "" {
+		return r.ItemID
+	}
+	return r.Name
+}
+
+// RecipeStep is a single preparation step.
+type RecipeStep struct {
+	Text string `json:"text"`
+}
+
+// Nutrition maps nutrient names to their values, e.g. "calories": "200".
+type Nutrition map[string]string
+
+func (r *Recipe) UnmarshalJSON(b []byte) error {
+	var content map[string]interface{}
+	if err := json.Unmarshal(b, &content); err != nil {
+		return err
+	}
+
+	*r = Recipe{
+		ID:         firstString(content, "uuid", "contentUuid", "id"),
+		Type:       stringValue(content["type"]),
+		Title:      firstString(content, "title", "name"),
+		Author:     firstString(content, "author", "attribution"),
+		LikeCount:  intValue(content["likeCount"]),
+		ImageURL:   imageURLFrom(content),
+		LinkOutURL: stringValue(content["linkOutUrl"]),
+		Tags:       stringSlice(content["tags"]),
+		Servings:   parseServings(content["yield"], content["baseQuantity"], content["servings"]),
+		Nutrition:  parseNutrition(content["nutrition"]),
+		Raw:        rawPayload(b),
+	}
+
+	items, _ := content["items"].([]interface{})
+	if len(items) == 0 {
+		items, _ = content["ingredients"].([]interface{})
+	}
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			if name := stringValue(item); name != "" {
+				r.Ingredients = append(r.Ingredients, RecipeIngredient{Name: name})
+			}
+			continue
+		}
+		ingredient := RecipeIngredient{
+			ItemID: stringValue(m["itemId"]),
+			Name:   firstString(m, "name", "text"),
+			Spec:   stringValue(m["spec"]),
+			Stock:  boolValue(m["stock"]),
+		}
+		if ingredient.ItemName() != "" {
+			r.Ingredients = append(r.Ingredients, ingredient)
+		}
+	}
+
+	steps := content["instructions"]
+	if steps == nil {
+		steps = content["steps"]
+	}
+	switch v := steps.(type) {
+	case []interface{}:
+		for _, step := range v {
+			text := stringValue(step)
+			if m, ok := step.(map[string]interface{}); ok {
+				text = firstString(m, "text", "description")
+			}
+			if text != "" {
+				r.Steps = append(r.Steps, RecipeStep{Text: text})
+			}
+		}
+	case string:
+		if v != "" {
+			r.Steps = []RecipeStep{{Text: v}}
+		}
+	}
+	return nil
+}
+
+func parseServings(values ...interface{}) int {
+	for _, value := range values {
+		if i := intValue(value); i > 0 {
+			return i
+		}
+	}
+	return 0
+}
+
+func parseNutrition(value interface{}) Nutrition {
+	raw, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	out := Nutrition{}
+	for key, v := range raw {
+		if s := stringValue(v); s != "" {
+			out[key] = s
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+var imageURLKeys = []string{"imageUrl", "imageURL", "thumbnailUrl", "previewImageUrl", "imagePreviewUrl", "imagePreviewUrlSquare"}
+
+func imageURLFrom(content map[string]interface{}) string {
+	if url := firstString(content, imageURLKeys...); url != "" {
+		return url
+	}
+	candidates := []interface{}{content["image"]}
+	if images, ok := content["images"].([]interface{}); ok {
+		candidates = append(candidates, images...)
+	}
+	for _, image := range candidates {
+		if url := stringValue(image); url != "" {
+			return url
+		}
+		if m, ok := image.(map[string]interface{}); ok {
+			if url := firstString(m, "url", "imageUrl"); url != "" {
+				return url
+			}
+		}
+	}
+	return ""
+}
+
+// rawPayload returns the API payload b was decoded from: b itself, or its
+// "raw" field when b is the marshalled form of an already decoded value.
+func rawPayload(b []byte) json.RawMessage {
+	var flat struct {
+		Raw json.RawMessage `json:"raw"`
+	}
+	if err := json.Unmarshal(b, &flat); err == nil && len(flat.Raw) > 0 {
+		return flat.Raw
+	}
+	return append(json.RawMessage(nil), b...)
+}
+
+func firstString(m map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		if s := stringValue(m[key]); s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+func stringValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return ""
+	}
+}
+
+func intValue(value interface{}) int {
+	switch v := value.(type) {
+	case float64:
+		return int(v)
+	case string:
+		if i, err := strconv.Atoi(v); err == nil {
+			return i
+		}
+	}
+	return 0
+}
+
+func boolValue(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true" || v == "1"
+	case float64:
+		return v != 0
+	default:
+		return false
+	}
+}
+
+func stringSlice(value interface{}) []string {
+	values, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if s := stringValue(v); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,131 @@
+package bring
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestRecipeUnmarshalNormalizesVariants(t *testing.T) {
+	payload := []byte(`{
+		"uuid": "recipe-1",
+		"name": "Pancakes",
+		"attribution": "Chef",
+		"baseQuantity": 4,
+		"ingredients": [
+			{"name": "Milk", "spec": "500 ml"},
+			{"itemId": "Salt", "spec": "1 tsp", "stock": "true"},
+			{"spec": "nameless"}
+		],
+		"steps": [{"description": "Mix"}, "Bake"],
+		"nutrition": {"calories": 200, "fat": "10 g"},
+		"image": {"url": "https://example.com/pancakes.jpg"},
+		"somethingNew": true
+	}`)
+
+	var recipe Recipe
+	if err := json.Unmarshal(payload, &recipe); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if recipe.ID != "recipe-1" || recipe.Title != "Pancakes" || recipe.Author != "Chef" {
+		t.Fatalf("unexpected recipe header: %#v", recipe)
+	}
+	if recipe.Servings != 4 {
+		t.Fatalf("unexpected servings: %d", recipe.Servings)
+	}
+	if len(recipe.Ingredients) != 2 {
+		t.Fatalf("unexpected ingredients: %#v", recipe.Ingredients)
+	}
+	if recipe.Ingredients[0].ItemName() != "Milk" || !recipe.Ingredients[1].Stock {
+		t.Fatalf("unexpected ingredients: %#v", recipe.Ingredients)
+	}
+	if len(recipe.Steps) != 2 || recipe.Steps[0].Text != "Mix" || recipe.Steps[1].Text != "Bake" {
+		t.Fatalf("unexpected steps: %#v", recipe.Steps)
+	}
+	if recipe.Nutrition["calories"] != "200" || recipe.Nutrition["fat"] != "10 g" {
+		t.Fatalf("unexpected nutrition: %#v", recipe.Nutrition)
+	}
+	if recipe.ImageURL != "https://example.com/pancakes.jpg" {
+		t.Fatalf("unexpected image: %s", recipe.ImageURL)
+	}
+	if len(recipe.Raw) == 0 {
+		t.Fatalf("expected raw payload to be kept")
+	}
+}
+
+func TestRecipeServingsVariants(t *testing.T) {
+	for payload, want := range map[string]int{
+		`{"yield": "2"}`:    2,
+		`{"servings": 3}`:   3,
+		`{"yield": ""}`:     0,
+		`{"yield": "four"}`: 0,
+	} {
+		var recipe Recipe
+		if err := json.Unmarshal([]byte(payload), &recipe); err != nil {
+			t.Fatalf("unmarshal %s: %v", payload, err)
+		}
+		if recipe.Servings != want {
+			t.Fatalf("%s: expected %d servings, got %d", payload, want, recipe.Servings)
+		}
+	}
+}
+
+func TestInspirationUnmarshalContentWrapper(t *testing.T) {
+	payload := []byte(`{"entries":[
+		{"uuid": "entry-1", "content": {"title": "Soup", "likeCount": 5, "tags": ["mine", "seasonal"], "thumbnailUrl": "https://example.com/soup.jpg"}},
+		{"contentUuid": "flat-1", "campaign": "Summer"}
+	],"total":2}`)
+
+	var resp GetInspirationsResponse
+	if err := json.Unmarshal(payload, &resp); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if len(resp.Entries) != 2 || resp.Total != 2 {
+		t.Fatalf("unexpected response: %#v", resp)
+	}
+	first := resp.Entries[0]
+	if first.ID != "entry-1" || first.Title != "Soup" || first.LikeCount != 5 || len(first.Tags) != 2 {
+		t.Fatalf("unexpected first entry: %#v", first)
+	}
+	if first.ImageURL != "https://example.com/soup.jpg" {
+		t.Fatalf("unexpected image: %s", first.ImageURL)
+	}
+	second := resp.Entries[1]
+	if second.ID != "flat-1" || second.Title != "Summer" {
+		t.Fatalf("unexpected second entry: %#v", second)
+	}
+}
+
+func TestRecipeAndInspirationRoundTrip(t *testing.T) {
+	var recipe Recipe
+	if err := json.Unmarshal([]byte(`{"uuid":"recipe-1","name":"Pancakes","attribution":"Chef","baseQuantity":4,
+		"ingredients":[{"name":"Milk","spec":"500 ml"},{"itemId":"Salt","stock":true}],
+		"steps":["Mix","Bake"],"nutrition":{"calories":"200"},"imageUrl":"https://example.com/p.jpg"}`), &recipe); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	var inspiration Inspiration
+	if err := json.Unmarshal([]byte(`{"uuid":"entry-1","content":{"contentUuid":"content-1","title":"Soup","likeCount":5,"tags":["mine"]}}`), &inspiration); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+
+	for _, value := range []interface{}{&recipe, &inspiration} {
+		data, err := json.Marshal(value)
+		if err != nil {
+			t.Fatalf("marshal failed: %v", err)
+		}
+		decoded := reflect.New(reflect.TypeOf(value).Elem()).Interface()
+		if err := json.Unmarshal(data, decoded); err != nil {
+			t.Fatalf("unmarshal of marshalled value failed: %v", err)
+		}
+		again, err := json.Marshal(decoded)
+		if err != nil {
+			t.Fatalf("marshal failed: %v", err)
+		}
+		if string(again) != string(data) {
+			t.Fatalf("value changed in round trip:\n%s\n%s", data, again)
+		}
+	}
+	if recipe.ID != "recipe-1" || inspiration.ID != "content-1" {
+		t.Fatalf("unexpected IDs: %q, %q", recipe.ID, inspiration.ID)
+	}
+}
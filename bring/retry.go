@@ -0,0 +1,152 @@
+package bring
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried.
+//
+// Idempotent requests (GET, DELETE and JSON PUTs) are retried on transport
+// errors and 5xx responses. Form PUTs and POSTs are only replayed when the
+// request provably did not reach the API: connection failures while dialing,
+// 429 and 503 responses. Set RetryNonIdempotent to replay them on any
+// retryable failure.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// A value of 1 disables retries.
+	MaxAttempts int
+	// BaseDelay is the backoff before the second attempt; it doubles after
+	// each further attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps the computed backoff. Retry-After values up to MaxDelay
+	// are honored as sent by the server; when the server asks for a longer
+	// wait, the request fails with an APIError carrying RetryAfter.
+	MaxDelay           time.Duration
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy is used when no policy is configured.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    10 * time.Second,
+}
+
+func (p RetryPolicy) normalized() RetryPolicy {
+	if p.MaxAttempts == 0 && p.BaseDelay == 0 && p.MaxDelay == 0 {
+		p.MaxAttempts = DefaultRetryPolicy.MaxAttempts
+		p.BaseDelay = DefaultRetryPolicy.BaseDelay
+		p.MaxDelay = DefaultRetryPolicy.MaxDelay
+	}
+	if p.MaxAttempts < 1 {
+		p.MaxAttempts = 1
+	}
+	if p.BaseDelay <= 0 {
+		p.BaseDelay = DefaultRetryPolicy.BaseDelay
+	}
+	if p.MaxDelay < p.BaseDelay {
+		p.MaxDelay = p.BaseDelay
+	}
+	return p
+}
+
+// backoff returns the delay before the attempt following the given one,
+// using exponential growth with equal jitter.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// send performs a request, retrying according to the client's retry policy.
+func (b *Bring) send(ctx context.Context, method, url string, headers map[string]string, payload []byte) (response, error) {
+	policy := b.retry.normalized()
+	replayable := policy.RetryNonIdempotent || isIdempotent(method, headers)
+
+	for attempt := 1; ; attempt++ {
+		resp, err := b.sendOnce(ctx, method, url, headers, payload)
+		if err == nil || attempt >= policy.MaxAttempts || ctx.Err() != nil || !shouldRetry(err, resp.status, replayable) {
+			return resp, err
+		}
+
+		delay := policy.backoff(attempt)
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+			if apiErr.RetryAfter > policy.MaxDelay {
+				return resp, err
+			}
+			delay = apiErr.RetryAfter
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return resp, err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return resp, err
+		case <-timer.C:
+		}
+	}
+}
+
+func isIdempotent(method string, headers map[string]string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodDelete:
+		return true
+	case http.MethodPut:
+		return !strings.HasPrefix(headers["Content-Type"], "application/x-www-form-urlencoded")
+	}
+	return false
+}
+
+func shouldRetry(err error, status int, replayable bool) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return replayable
+	case 0:
+		return replayable || isDialError(err)
+	}
+	return false
+}
+
+// isDialError reports whether err happened before the request was written.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func parseRetryAfter(value string) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(at); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
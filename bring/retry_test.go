@@ -0,0 +1,132 @@
+package bring
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+var fastRetry = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+func TestRetryTransientServerErrors(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"lists":[{"listUuid":"list-1","name":"Groceries"}]}`))
+	}))
+	defer server.Close()
+
+	client := FromToken(TokenAuthOptions{AccessToken: "access-token", UserUUID: "user-uuid", URL: server.URL, Retry: fastRetry})
+	lists, err := client.LoadLists(context.Background())
+	if err != nil {
+		t.Fatalf("load lists failed: %v", err)
+	}
+	if calls != 3 || len(lists.Lists) != 1 {
+		t.Fatalf("unexpected calls %d or lists %#v", calls, lists)
+	}
+}
+
+func TestRetryFormPutOnlyWhenNotProcessed(t *testing.T) {
+	status := http.StatusInternalServerError
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(status)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := FromToken(TokenAuthOptions{AccessToken: "access-token", UserUUID: "user-uuid", URL: server.URL, Retry: fastRetry})
+	if _, err := client.SaveItem(context.Background(), "list-1", "Milk", ""); err == nil {
+		t.Fatalf("expected form PUT not to be replayed after a 500")
+	}
+	if calls != 1 {
+		t.Fatalf("expected a single attempt, got %d", calls)
+	}
+
+	status = http.StatusServiceUnavailable
+	calls = 0
+	if _, err := client.SaveItem(context.Background(), "list-1", "Milk", ""); err != nil {
+		t.Fatalf("expected replay after a 503: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected two attempts, got %d", calls)
+	}
+}
+
+func TestRetryAfterRespectsContextDeadline(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := FromToken(TokenAuthOptions{AccessToken: "access-token", UserUUID: "user-uuid", URL: server.URL, Retry: fastRetry})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.LoadLists(ctx)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected rate limited error, got %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.RetryAfter != 30*time.Second {
+		t.Fatalf("expected Retry-After to be parsed, got %#v", apiErr)
+	}
+	if calls != 1 || time.Since(start) > 500*time.Millisecond {
+		t.Fatalf("expected immediate failure, got %d calls in %s", calls, time.Since(start))
+	}
+}
+
+func TestRetryAfterBeyondMaxDelayFails(t *testing.T) {
+	for _, retryAfter := range []string{"3600", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)} {
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.Header().Set("Retry-After", retryAfter)
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+
+		client := FromToken(TokenAuthOptions{AccessToken: "access-token", UserUUID: "user-uuid", URL: server.URL, Retry: fastRetry})
+		start := time.Now()
+		_, err := client.LoadLists(context.Background())
+		server.Close()
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.RetryAfter < 59*time.Minute {
+			t.Fatalf("Retry-After %q: expected the error to carry the wait, got %v", retryAfter, err)
+		}
+		if calls != 1 || time.Since(start) > 500*time.Millisecond {
+			t.Fatalf("Retry-After %q: expected immediate failure, got %d calls in %s", retryAfter, calls, time.Since(start))
+		}
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}.normalized()
+	for attempt, max := range map[int]time.Duration{1: 100, 2: 200, 3: 300, 4: 300} {
+		delay := policy.backoff(attempt)
+		if delay < max*time.Millisecond/2 || delay > max*time.Millisecond {
+			t.Fatalf("attempt %d: unexpected delay %s", attempt, delay)
+		}
+	}
+	if got := (RetryPolicy{}).normalized(); got.MaxAttempts != DefaultRetryPolicy.MaxAttempts {
+		t.Fatalf("zero policy should use defaults, got %#v", got)
+	}
+	if got := parseRetryAfter("2"); got != 2*time.Second {
+		t.Fatalf("unexpected Retry-After: %s", got)
+	}
+}
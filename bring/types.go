@@ -7,18 +7,33 @@ type BringOptions struct {
 	Password string
 	URL      string
 	UUID     string
+	// Retry configures request retries; the zero value uses DefaultRetryPolicy.
+	Retry RetryPolicy
 }
 
 type TokenAuthOptions struct {
 	AccessToken    string
+	RefreshToken   string
 	UserUUID       string
 	PublicUserUUID string
 	URL            string
+	Retry          RetryPolicy
+	// OnTokenRefresh is called after the access token has been refreshed.
+	OnTokenRefresh func(AuthTokens)
+}
+
+// AuthTokens holds the credentials issued by a token refresh.
+type AuthTokens struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    int
 }
 
 type GetItemsResponseEntry struct {
-	Specification string `json:"specification"`
-	Name          string `json:"name"`
+	Specification string         `json:"specification"`
+	Name          string         `json:"name"`
+	UUID          string         `json:"uuid,omitempty"`
+	Attributes    ItemAttributes `json:"attributes"`
 }
 
 type GetItemsResponse struct {
@@ -36,6 +51,13 @@ type AuthSuccessResponse struct {
 	RefreshToken string `json:"refresh_token"`
 }
 
+type AuthTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
 type ErrorResponse struct {
 	Message          string `json:"message"`
 	Error            string `json:"error"`
@@ -68,6 +90,20 @@ type LoadListsResponse struct {
 	Lists []LoadListsEntry `json:"lists"`
 }
 
+type CreateListResponse struct {
+	BringListUUID string `json:"bringListUUID"`
+	ListUUID      string `json:"listUuid"`
+}
+
+// List themes known to the Bring! apps.
+const (
+	ThemeHome    = "ch.publisheria.bring.theme.home"
+	ThemeBBQ     = "ch.publisheria.bring.theme.bbq"
+	ThemeOffice  = "ch.publisheria.bring.theme.office"
+	ThemeHoliday = "ch.publisheria.bring.theme.holiday"
+	ThemeParty   = "ch.publisheria.bring.theme.party"
+)
+
 type GetItemsDetailsEntry struct {
 	UUID           string `json:"uuid"`
 	ItemID         string `json:"itemId"`
@@ -155,15 +191,15 @@ func (l LocaleValue) String() string {
 }
 
 type GetActivityResponse struct {
-	Timeline    []map[string]interface{} `json:"timeline"`
-	Timestamp   string                   `json:"timestamp"`
-	TotalEvents int                      `json:"totalEvents"`
+	Timeline    []ActivityEvent `json:"timeline"`
+	Timestamp   string          `json:"timestamp"`
+	TotalEvents int             `json:"totalEvents"`
 }
 
 type GetInspirationsResponse struct {
-	Entries []map[string]interface{} `json:"entries"`
-	Count   int                      `json:"count"`
-	Total   int                      `json:"total"`
+	Entries []Inspiration `json:"entries"`
+	Count   int           `json:"count"`
+	Total   int           `json:"total"`
 }
 
 type GetInspirationFiltersResponse struct {
@@ -189,7 +225,7 @@ type LoadCatalogResponse struct {
 }
 
 type GetPendingInvitationsResponse struct {
-	Invitations []map[string]interface{} `json:"invitations"`
+	Invitations []Invitation `json:"invitations"`
 }
 
 type BringItemOperation string
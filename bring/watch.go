@@ -0,0 +1,181 @@
+package bring
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// DefaultWatchInterval is the polling interval used when WatchOptions does
+// not set one.
+const DefaultWatchInterval = 10 * time.Second
+
+// ChangeType is the kind of a ListChange.
+type ChangeType string
+
+const (
+	// ChangeAdded is an item that appeared among the items to purchase,
+	// including recent items put back on the list.
+	ChangeAdded ChangeType = "added"
+	// ChangeRemoved is an item to purchase that left the list entirely.
+	ChangeRemoved ChangeType = "removed"
+	// ChangeCompleted is an item to purchase that moved to the recent items.
+	ChangeCompleted ChangeType = "completed"
+	// ChangeSpecChanged is an item to purchase whose specification changed.
+	ChangeSpecChanged ChangeType = "spec_changed"
+)
+
+// ListChange is a change of the items to purchase between two polls.
+type ListChange struct {
+	Type     ChangeType `json:"type"`
+	ListUUID string     `json:"listUuid"`
+	// Item is the item as of the poll that noticed the change; for
+	// ChangeRemoved it is the item as last seen.
+	Item ListItem `json:"item"`
+	// PreviousSpec is set for ChangeSpecChanged.
+	PreviousSpec string    `json:"previousSpec,omitempty"`
+	Time         time.Time `json:"time"`
+}
+
+// WatchOptions configures Watch.
+type WatchOptions struct {
+	// Interval is the time between polls, DefaultWatchInterval if zero.
+	Interval time.Duration
+	// OnError is called from the watching goroutine when a poll fails.
+	// Watch keeps polling; cancel the context to stop it.
+	OnError func(error)
+}
+
+// Watch polls the items of a list and sends the changes between successive
+// polls. The first poll only records the current state. The channel is
+// closed once ctx is done.
+func Watch(ctx context.Context, client *Bring, listUUID string, opts WatchOptions) <-chan ListChange {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+	changes := make(chan ListChange)
+
+	go func() {
+		defer close(changes)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var previous []ListItem
+		polled := false
+		for {
+			items, err := client.GetListItems(ctx, listUUID)
+			switch {
+			case ctx.Err() != nil:
+				return
+			case err != nil:
+				if opts.OnError != nil {
+					opts.OnError(err)
+				}
+			case !polled:
+				previous, polled = items, true
+			default:
+				for _, change := range diffItems(listUUID, previous, items, time.Now()) {
+					select {
+					case changes <- change:
+					case <-ctx.Done():
+						return
+					}
+				}
+				previous = items
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return changes
+}
+
+// diffItems returns the changes of the items to purchase from before to
+// after, pairing items as matchSnapshots does.
+func diffItems(listUUID string, before, after []ListItem, now time.Time) []ListChange {
+	pairs := matchSnapshots(before, after)
+	previousOf := map[int]int{}
+	for i, j := range pairs {
+		previousOf[j] = i
+	}
+
+	var changes []ListChange
+	change := func(changeType ChangeType, item ListItem) ListChange {
+		return ListChange{Type: changeType, ListUUID: listUUID, Item: item, Time: now}
+	}
+	for j, item := range after {
+		if item.Status != BringItemToPurchase {
+			continue
+		}
+		i, ok := previousOf[j]
+		switch {
+		case !ok || before[i].Status != BringItemToPurchase:
+			changes = append(changes, change(ChangeAdded, item))
+		case before[i].Spec != item.Spec:
+			specChanged := change(ChangeSpecChanged, item)
+			specChanged.PreviousSpec = before[i].Spec
+			changes = append(changes, specChanged)
+		}
+	}
+	for i, item := range before {
+		if item.Status != BringItemToPurchase {
+			continue
+		}
+		j, ok := pairs[i]
+		switch {
+		case !ok:
+			changes = append(changes, change(ChangeRemoved, item))
+		case after[j].Status == BringItemToRecently:
+			changes = append(changes, change(ChangeCompleted, after[j]))
+		}
+	}
+	return changes
+}
+
+// matchSnapshots pairs the items of two polls, returning the position in
+// after for each matched position in before. Items are paired by UUID.
+// Items without one are paired by name, preferring the same spec and status,
+// then the same spec, then any, so that duplicate names with different specs
+// stay apart.
+func matchSnapshots(before, after []ListItem) map[int]int {
+	pairs := map[int]int{}
+	matched := map[int]bool{}
+	byUUID := map[string]int{}
+	for i, item := range before {
+		if item.UUID != "" {
+			byUUID[item.UUID] = i
+		}
+	}
+	for j, item := range after {
+		if i, ok := byUUID[item.UUID]; ok && item.UUID != "" {
+			pairs[i], matched[j] = j, true
+		}
+	}
+
+	passes := []func(old, item ListItem) bool{
+		func(old, item ListItem) bool { return old.Spec == item.Spec && old.Status == item.Status },
+		func(old, item ListItem) bool { return old.Spec == item.Spec },
+		func(old, item ListItem) bool { return true },
+	}
+	for _, same := range passes {
+		for j, item := range after {
+			if matched[j] {
+				continue
+			}
+			for i, old := range before {
+				_, paired := pairs[i]
+				if paired || (old.UUID != "" && item.UUID != "") || !strings.EqualFold(old.ItemID, item.ItemID) || !same(old, item) {
+					continue
+				}
+				pairs[i], matched[j] = j, true
+				break
+			}
+		}
+	}
+	return pairs
+}
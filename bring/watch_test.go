@@ -0,0 +1,112 @@
+package bring
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDiffItems(t *testing.T) {
+	before := []ListItem{
+		{UUID: "milk-1", ItemID: "Milk", Spec: "2 L", Status: BringItemToPurchase},
+		{UUID: "eggs-1", ItemID: "Eggs", Status: BringItemToPurchase},
+		{UUID: "bread-1", ItemID: "Bread", Status: BringItemToPurchase},
+		{ItemID: "Salt", Status: BringItemToRecently},
+	}
+	after := []ListItem{
+		{UUID: "milk-1", ItemID: "Milk", Spec: "3 L", Status: BringItemToPurchase},
+		{UUID: "butter-1", ItemID: "Butter", Status: BringItemToPurchase},
+		{ItemID: "Salt", Status: BringItemToPurchase},
+		{UUID: "bread-1", ItemID: "Bread", Status: BringItemToRecently},
+	}
+
+	changes := diffItems("list-1", before, after, time.Now())
+	var got []string
+	for _, change := range changes {
+		got = append(got, string(change.Type)+" "+change.Item.ItemID)
+	}
+	want := []string{"spec_changed Milk", "added Butter", "added Salt", "removed Eggs", "completed Bread"}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected changes: %v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("unexpected changes: %v", got)
+		}
+	}
+	if changes[0].PreviousSpec != "2 L" || changes[0].Item.Spec != "3 L" || changes[0].ListUUID != "list-1" {
+		t.Fatalf("unexpected spec change: %+v", changes[0])
+	}
+	if len(diffItems("list-1", after, after, time.Now())) != 0 {
+		t.Fatal("expected no changes between identical snapshots")
+	}
+}
+
+func TestDiffItemsWithDuplicateNames(t *testing.T) {
+	before := []ListItem{
+		{ItemID: "Milk", Spec: "2 L", Status: BringItemToPurchase},
+		{ItemID: "Milk", Spec: "oat", Status: BringItemToPurchase},
+		{ItemID: "Apples", Spec: "red", Status: BringItemToPurchase},
+		{ItemID: "Apples", Spec: "green", Status: BringItemToPurchase},
+		{ItemID: "Pears", Spec: "1 kg", Status: BringItemToPurchase},
+	}
+	after := []ListItem{
+		{ItemID: "Milk", Spec: "2 L", Status: BringItemToPurchase},
+		{ItemID: "Apples", Spec: "green", Status: BringItemToPurchase},
+		{ItemID: "Pears", Spec: "2 kg", Status: BringItemToPurchase},
+		{ItemID: "Milk", Spec: "oat", Status: BringItemToRecently},
+	}
+
+	var got []string
+	for _, change := range diffItems("list-1", before, after, time.Now()) {
+		got = append(got, string(change.Type)+" "+change.Item.String())
+	}
+	want := []string{"spec_changed Pears (2 kg)", "completed Milk (oat)", "removed Apples (red)"}
+	if strings.Join(got, "; ") != strings.Join(want, "; ") {
+		t.Fatalf("unexpected changes: %v", got)
+	}
+}
+
+func TestWatchStreamsChanges(t *testing.T) {
+	var mu sync.Mutex
+	purchase := `[{"name":"Milk","uuid":"milk-1"}]`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/bringlists/list-1":
+			_, _ = w.Write([]byte(`{"purchase":` + purchase + `,"recently":[]}`))
+			purchase = `[{"name":"Milk","uuid":"milk-1"},{"name":"Eggs","uuid":"eggs-1"}]`
+		case "/bringlists/list-1/details":
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := FromToken(TokenAuthOptions{AccessToken: "access-token", UserUUID: "user-uuid", URL: server.URL})
+	changes := Watch(ctx, client, "list-1", WatchOptions{
+		Interval: 10 * time.Millisecond,
+		OnError:  func(err error) { t.Errorf("unexpected error: %v", err) },
+	})
+
+	select {
+	case change := <-changes:
+		if change.Type != ChangeAdded || change.Item.ItemID != "Eggs" || change.Item.UUID != "eggs-1" {
+			t.Fatalf("unexpected change: %+v", change)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a change")
+	}
+
+	cancel()
+	for range changes {
+	}
+}
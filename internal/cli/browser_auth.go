@@ -19,6 +19,7 @@ const bringAppURL = "https://web.getbring.com/app"
 // BrowserAuthResult holds auth data extracted from browser login.
 type BrowserAuthResult struct {
 	AccessToken    string
+	RefreshToken   string
 	UserUUID       string
 	PublicUserUUID string
 	UserName       string
@@ -163,6 +164,7 @@ func BrowserLoginWithIntercept(ctx context.Context) (BrowserAuthResult, error) {
 	if payload, ok := waitForAuthResponse(authResponseCh, 10*time.Second); ok {
 		return finalizeAuthResult(BrowserAuthResult{
 			AccessToken:    payload.AccessToken,
+			RefreshToken:   payload.RefreshToken,
 			UserUUID:       payload.UUID,
 			PublicUserUUID: payload.PublicUUID,
 			UserName:       payload.Name,
@@ -7,9 +7,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
 	"math"
 	"os"
+	"os/signal"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -20,62 +23,57 @@ import (
 
 const bringWebURL = "https://web.getbring.com/app"
 
+// Exit codes returned by Run.
+const (
+	exitOK           = 0
+	exitError        = 1
+	exitUnauthorized = 3
+	exitNotFound     = 4
+	exitRateLimited  = 5
+)
+
 // Run executes the CLI and returns an exit code.
 func Run(args []string) int {
-	command, flags, positional := parseArgs(args)
-
-	if flags.Has("help") || flags.Has("h") || command == "help" {
+	if len(args) == 0 || args[0] == "--help" || args[0] == "-h" {
 		showHelp()
 		return 0
 	}
+	if args[0] == "help" {
+		if len(args) == 1 {
+			showHelp()
+			return 0
+		}
+		cmd := findCommand(args[1])
+		if cmd == nil {
+			return unknownCommand(args[1])
+		}
+		showCommandHelp(cmd)
+		return 0
+	}
 
-	switch command {
-	case "login":
-		return loginCommand(flags)
-	case "logout":
-		return logoutCommand()
-	case "status":
-		return statusCommand()
-	case "lists":
-		return listsCommand()
-	case "items":
-		return itemsCommand(positional, flags)
-	case "add":
-		return addCommand(positional, flags)
-	case "remove", "rm":
-		return removeCommand(positional, flags)
-	case "complete", "done":
-		return completeCommand(positional, flags)
-	case "users":
-		return usersCommand(flags)
-	case "notify":
-		return notifyCommand(positional, flags)
-	case "activity":
-		return activityCommand(flags)
-	case "account":
-		return accountCommand()
-	case "settings":
-		return settingsCommand()
-	case "config":
-		return configCommand(positional)
-	case "inspirations":
-		return inspirationsCommand(positional, flags)
-	case "recipe":
-		return recipeCommand(positional, flags)
-	case "add-recipe":
-		return addRecipeCommand(positional, flags)
-	case "catalog":
-		return catalogCommand(positional)
-	case "":
-		showHelp()
+	cmd := findCommand(args[0])
+	if cmd == nil {
+		return unknownCommand(args[0])
+	}
+	flags, positional, err := cmd.parseFlags(args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		fmt.Fprintf(os.Stderr, "Run `brings help %s` for usage\n", cmd.Name)
+		return 1
+	}
+	if flags.Has("help") {
+		showCommandHelp(cmd)
 		return 0
-	default:
-		fmt.Fprintf(os.Stderr, "Unknown command: %s\n", command)
-		fmt.Fprintln(os.Stderr, "Run `brings --help` for usage")
+	}
+	if _, _, err := parseOutputFormat(flags, "human"); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
 		return 1
 	}
+	return cmd.Run(positional, flags)
 }
 
+// FlagSet holds the flags given to a command, keyed by their canonical name.
+// Bools records boolean flags, including ones explicitly set to false.
 type FlagSet struct {
 	Values map[string]string
 	Bools  map[string]bool
@@ -111,60 +109,176 @@ type inspirationFiltersOutput struct {
 	Filters []inspirationFilterOutput `json:"filters"`
 }
 
+type listOutput struct {
+	ListUUID string `json:"listUuid"`
+	Name     string `json:"name"`
+	Theme    string `json:"theme,omitempty"`
+}
+
+type listsOutput struct {
+	Lists []listOutput `json:"lists"`
+}
+
+type itemOutput struct {
+	// Index addresses items to purchase in remove, complete and other item
+	// commands, and recent items in readd.
+	Index int `json:"index,omitempty"`
+	bring.ListItem
+	Assignee string `json:"assignee,omitempty"`
+}
+
+type itemsOutput struct {
+	ListUUID string       `json:"listUuid"`
+	List     string       `json:"list,omitempty"`
+	Purchase []itemOutput `json:"purchase"`
+	Recently []itemOutput `json:"recently,omitempty"`
+}
+
+type recentOutput struct {
+	ListUUID string       `json:"listUuid"`
+	List     string       `json:"list"`
+	Recently []itemOutput `json:"recently"`
+}
+
 type recipeIngredientOutput struct {
-	Name   string `json:"name,omitempty"`
-	Spec   string `json:"spec,omitempty"`
-	Pantry bool   `json:"pantry,omitempty"`
+	Name string `json:"name,omitempty"`
+	// Spec is scaled to the target servings; RawSpec is the recipe's own.
+	Spec    string `json:"spec,omitempty"`
+	RawSpec string `json:"rawSpec,omitempty"`
+	Pantry  bool   `json:"pantry,omitempty"`
 }
 
+// recipeSchemaVersion is the version of the recipe JSON shape. Fields may be
+// added within a version; renaming or removing one requires a new version.
+const recipeSchemaVersion = 1
+
 type recipeOutput struct {
-	ID        string            `json:"id"`
-	Title     string            `json:"title,omitempty"`
-	ImageURL  string            `json:"imageUrl,omitempty"`
-	Nutrition map[string]string `json:"nutrition,omitempty"`
+	SchemaVersion  int                      `json:"schemaVersion"`
+	ID             string                   `json:"id"`
+	Title          string                   `json:"title,omitempty"`
+	Author         string                   `json:"author,omitempty"`
+	Likes          int                      `json:"likes,omitempty"`
+	ImageURL       string                   `json:"imageUrl,omitempty"`
+	SourceURL      string                   `json:"sourceUrl,omitempty"`
+	Servings       int                      `json:"servings,omitempty"`
+	TargetServings int                      `json:"targetServings,omitempty"`
+	Scale          float64                  `json:"scale"`
+	Ingredients    []recipeIngredientOutput `json:"ingredients"`
+	Instructions   []string                 `json:"instructions"`
+	Nutrition      bring.Nutrition          `json:"nutrition,omitempty"`
 }
 
-func parseArgs(args []string) (string, FlagSet, []string) {
-	flags := FlagSet{Values: map[string]string{}, Bools: map[string]bool{}}
-	positional := []string{}
-	command := ""
+type statusOutput struct {
+	LoggedIn     bool       `json:"loggedIn"`
+	Name         string     `json:"name,omitempty"`
+	Email        string     `json:"email,omitempty"`
+	Config       string     `json:"config"`
+	TokenExpires *time.Time `json:"tokenExpires,omitempty"`
+	TokenExpired bool       `json:"tokenExpired,omitempty"`
+	AutoRefresh  bool       `json:"autoRefresh"`
+}
 
-	for i := 0; i < len(args); i++ {
-		arg := args[i]
-		if i == 0 && !strings.HasPrefix(arg, "-") {
-			command = arg
-			continue
-		}
+type activityOutput struct {
+	ListUUID    string                `json:"listUuid"`
+	List        string                `json:"list,omitempty"`
+	TotalEvents int                   `json:"totalEvents"`
+	Events      []bring.ActivityEvent `json:"events"`
+}
 
-		if strings.HasPrefix(arg, "--") {
-			keyValue := strings.SplitN(arg[2:], "=", 2)
-			key := keyValue[0]
-			if len(keyValue) == 2 {
-				flags.Values[key] = keyValue[1]
-				continue
-			}
-			if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
-				flags.Values[key] = args[i+1]
-				i++
-				continue
-			}
-			flags.Bools[key] = true
-			continue
-		}
+type usersOutput struct {
+	ListUUID string                           `json:"listUuid"`
+	List     string                           `json:"list,omitempty"`
+	Users    []bring.GetAllUsersFromListEntry `json:"users"`
+}
 
-		if strings.HasPrefix(arg, "-") {
-			flags.Bools[arg[1:]] = true
-			continue
-		}
+// accountOutput flattens the account locale, which the API sends as either
+// a string or an object.
+type accountOutput struct {
+	Name          string          `json:"name,omitempty"`
+	Email         string          `json:"email"`
+	EmailVerified bool            `json:"emailVerified"`
+	Locale        string          `json:"locale,omitempty"`
+	UserUUID      string          `json:"userUuid"`
+	PublicUUID    string          `json:"publicUserUuid"`
+	PhotoPath     string          `json:"photoPath,omitempty"`
+	Premium       map[string]bool `json:"premiumConfiguration,omitempty"`
+}
+
+type invitationOutput struct {
+	InvitationUUID string     `json:"invitationUuid"`
+	ListUUID       string     `json:"listUuid,omitempty"`
+	List           string     `json:"list,omitempty"`
+	Inviter        string     `json:"inviter,omitempty"`
+	InviterEmail   string     `json:"inviterEmail,omitempty"`
+	Status         string     `json:"status,omitempty"`
+	Time           *time.Time `json:"time,omitempty"`
+}
+
+type invitesOutput struct {
+	Invitations []invitationOutput `json:"invitations"`
+}
+
+// settingsOutput holds the account settings and the per-list settings, each
+// as a key-value map.
+type settingsOutput struct {
+	Settings map[string]string    `json:"settings"`
+	Lists    []listSettingsOutput `json:"lists"`
+}
+
+type listSettingsOutput struct {
+	ListUUID string            `json:"listUuid"`
+	Settings map[string]string `json:"settings"`
+}
+
+type catalogItemOutput struct {
+	ItemID string `json:"itemId"`
+	Name   string `json:"name"`
+}
+
+type catalogSectionOutput struct {
+	SectionID string              `json:"sectionId"`
+	Name      string              `json:"name"`
+	Items     []catalogItemOutput `json:"items"`
+}
+
+type catalogOutput struct {
+	Locale   string                 `json:"locale"`
+	Sections []catalogSectionOutput `json:"sections"`
+}
+
+var configKeys = []string{"servings", "defaultList", "locale"}
+
+type configOutput struct {
+	Servings    int    `json:"servings,omitempty"`
+	DefaultList string `json:"defaultList,omitempty"`
+	Locale      string `json:"locale,omitempty"`
+	Path        string `json:"path"`
+}
 
-		positional = append(positional, arg)
+// get returns the value of a config key as text, or "" when it is not set.
+func (c configOutput) get(key string) string {
+	switch key {
+	case "servings":
+		if c.Servings == 0 {
+			return ""
+		}
+		return strconv.Itoa(c.Servings)
+	case "defaultList":
+		return c.DefaultList
+	case "locale":
+		return c.Locale
 	}
+	return ""
+}
 
-	return command, flags, positional
+type configEntryOutput struct {
+	Key   string `json:"key"`
+	Value string `json:"value,omitempty"`
 }
 
+// prompt asks on stderr so that stdout only carries command output.
 func prompt(question string) (string, error) {
-	fmt.Print(question)
+	fmt.Fprint(os.Stderr, question)
 	reader := bufio.NewReader(os.Stdin)
 	text, err := reader.ReadString('\n')
 	if err != nil {
@@ -207,22 +321,28 @@ func decodeJWT(token string) (jwtClaims, error) {
 	return claims, nil
 }
 
-func loginCommand(flags FlagSet) int {
+func loginCommand(positional []string, flags FlagSet) int {
+	out := outputFor(flags)
 	baseURL := getBaseURL()
-	if flags.Has("browser") || flags.Has("b") {
-		result, err := BrowserLoginWithIntercept(context.Background())
+	if flags.Has("browser") {
+		auth, err := BrowserLoginWithIntercept(context.Background())
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "\nError: Browser login failed - %s\n", err)
 			return 1
 		}
 
-		fmt.Println("Validating token...")
+		out.progress("Validating token...\n")
+		opts, err := clientOptions()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+			return 1
+		}
 		client := bring.FromToken(bring.TokenAuthOptions{
-			AccessToken:    result.AccessToken,
-			UserUUID:       result.UserUUID,
-			PublicUserUUID: result.PublicUserUUID,
+			AccessToken:    auth.AccessToken,
+			UserUUID:       auth.UserUUID,
+			PublicUserUUID: auth.PublicUserUUID,
 			URL:            baseURL,
-		})
+		}, opts...)
 		account, err := client.GetUserAccount(context.Background())
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "\nError: Failed to validate token - %s\n", err)
@@ -230,40 +350,33 @@ func loginCommand(flags FlagSet) int {
 		}
 
 		cfg := Config{
-			AccessToken:    result.AccessToken,
+			AccessToken:    auth.AccessToken,
+			RefreshToken:   auth.RefreshToken,
 			UserUUID:       account.UserUUID,
 			PublicUserUUID: account.PublicUserUUID,
-			UserName:       coalesce(account.Name, result.UserName),
-			Email:          coalesce(account.Email, result.Email),
+			UserName:       coalesce(account.Name, auth.UserName),
+			Email:          coalesce(account.Email, auth.Email),
 		}
 		if err := saveConfig(cfg); err != nil {
 			fmt.Fprintf(os.Stderr, "Error saving config: %s\n", err)
 			return 1
 		}
-
-		fmt.Printf("\nLogged in as %s\n", coalesce(account.Name, account.Email))
-		fmt.Printf("Config saved to %s\n", getConfigPath())
-		return 0
+		return out.result(loginResult(cfg))
 	}
 
 	token := flags.Get("token")
 	if token == "" {
-		fmt.Println()
-		fmt.Println("To login, you need to extract your access token from the Bring! web app.")
-		fmt.Println()
-		fmt.Println("Steps:")
-		fmt.Printf("  1. Open %s in your browser\n", bringWebURL)
-		fmt.Println("  2. Log in with your credentials")
-		fmt.Println("  3. Open DevTools (F12) -> Application tab -> Local Storage")
-		fmt.Println("  4. Find the \"accessToken\" key and copy its value")
-		fmt.Println()
-		fmt.Println("Or use `brings login --browser` for automatic browser-based login.")
-		fmt.Println()
+		out.progress("\nTo login, you need to extract your access token from the Bring! web app.\n\n")
+		out.progress("Steps:\n")
+		out.progress("  1. Open %s in your browser\n", bringWebURL)
+		out.progress("  2. Log in with your credentials\n")
+		out.progress("  3. Open DevTools (F12) -> Application tab -> Local Storage\n")
+		out.progress("  4. Find the \"accessToken\" key and copy its value\n\n")
+		out.progress("Or use `brings login --browser` for automatic browser-based login.\n\n")
 
 		entered, err := prompt("Paste your access token: ")
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-			return 1
+			return reportError(err)
 		}
 		token = entered
 	}
@@ -290,8 +403,13 @@ func loginCommand(flags FlagSet) int {
 	parts := strings.Split(decoded.Sub, ":")
 	userUUID := parts[len(parts)-1]
 
-	fmt.Println("\nValidating token...")
-	client := bring.FromToken(bring.TokenAuthOptions{AccessToken: token, UserUUID: userUUID, URL: baseURL})
+	out.progress("\nValidating token...\n")
+	opts, err := clientOptions()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		return 1
+	}
+	client := bring.FromToken(bring.TokenAuthOptions{AccessToken: token, UserUUID: userUUID, URL: baseURL}, opts...)
 	account, err := client.GetUserAccount(context.Background())
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "\nError: Failed to validate token - %s\n", err)
@@ -301,6 +419,7 @@ func loginCommand(flags FlagSet) int {
 
 	cfg := Config{
 		AccessToken:    token,
+		RefreshToken:   flags.Get("refresh-token"),
 		UserUUID:       account.UserUUID,
 		PublicUserUUID: account.PublicUserUUID,
 		UserName:       account.Name,
@@ -310,378 +429,1532 @@ func loginCommand(flags FlagSet) int {
 		fmt.Fprintf(os.Stderr, "Error saving config: %s\n", err)
 		return 1
 	}
+	return out.result(loginResult(cfg))
+}
 
-	fmt.Printf("\nLogged in as %s\n", coalesce(account.Name, account.Email))
-	fmt.Printf("Config saved to %s\n", getConfigPath())
-	return 0
+func loginResult(cfg Config) result {
+	return result{
+		Action:   "login",
+		User:     coalesce(cfg.UserName, cfg.Email),
+		UserUUID: cfg.PublicUserUUID,
+		Message:  fmt.Sprintf("Logged in as %s\nConfig saved to %s", coalesce(cfg.UserName, cfg.Email), getConfigPath()),
+	}
 }
 
-func logoutCommand() int {
+func logoutCommand(positional []string, flags FlagSet) int {
+	out := outputFor(flags)
 	if !isLoggedIn() {
-		fmt.Println("Not logged in")
-		return 0
+		return out.result(result{Action: "logout", Message: "Not logged in"})
 	}
 	if err := clearConfig(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-		return 1
+		return reportError(err)
 	}
-	fmt.Println("Logged out successfully")
-	return 0
+	return out.result(result{Action: "logout", Message: "Logged out successfully"})
 }
 
-func statusCommand() int {
+func statusCommand(positional []string, flags FlagSet) int {
 	cfg := loadConfig()
-	if cfg.AccessToken == "" {
-		fmt.Println("Not logged in")
-		fmt.Println("\nRun `brings login` to authenticate")
-		return 0
-	}
-
-	fmt.Println("Logged in")
-	if cfg.UserName != "" {
-		fmt.Printf("  Name: %s\n", cfg.UserName)
-	}
-	if cfg.Email != "" {
-		fmt.Printf("  Email: %s\n", cfg.Email)
-	}
-	fmt.Printf("  Config: %s\n", getConfigPath())
-
-	decoded, err := decodeJWT(cfg.AccessToken)
-	if err == nil && decoded.Exp > 0 {
+	status := statusOutput{
+		LoggedIn:    cfg.AccessToken != "",
+		Name:        cfg.UserName,
+		Email:       cfg.Email,
+		Config:      getConfigPath(),
+		AutoRefresh: cfg.RefreshToken != "",
+	}
+	if decoded, err := decodeJWT(cfg.AccessToken); status.LoggedIn && err == nil && decoded.Exp > 0 {
 		exp := time.Unix(decoded.Exp, 0)
-		if exp.Before(time.Now()) {
+		status.TokenExpires = &exp
+		status.TokenExpired = exp.Before(time.Now())
+	}
+	return outputFor(flags).print(status, func() {
+		if !status.LoggedIn {
+			fmt.Println("Not logged in")
+			fmt.Println("\nRun `brings login` to authenticate")
+			return
+		}
+		fmt.Println("Logged in")
+		if status.Name != "" {
+			fmt.Printf("  Name: %s\n", status.Name)
+		}
+		if status.Email != "" {
+			fmt.Printf("  Email: %s\n", status.Email)
+		}
+		fmt.Printf("  Config: %s\n", status.Config)
+		switch {
+		case status.TokenExpires == nil:
+		case status.TokenExpired && status.AutoRefresh:
+			fmt.Println("  Token expired: it will be refreshed on the next request")
+		case status.TokenExpired:
 			fmt.Println("\n  Warning: Token has expired! Run `brings login` to refresh.")
-		} else {
-			daysLeft := int(math.Ceil(exp.Sub(time.Now()).Hours() / 24))
-			fmt.Printf("  Token expires: %s (%d days)\n", exp.Format("2006-01-02"), daysLeft)
+		default:
+			daysLeft := int(math.Ceil(time.Until(*status.TokenExpires).Hours() / 24))
+			fmt.Printf("  Token expires: %s (%d days)\n", status.TokenExpires.Format("2006-01-02"), daysLeft)
 		}
-	}
-	return 0
+		if status.AutoRefresh {
+			fmt.Println("  Auto-refresh: enabled")
+		}
+	})
 }
 
-func listsCommand() int {
+func listsCommand(positional []string, flags FlagSet) int {
+	if len(positional) > 0 {
+		switch positional[0] {
+		case "create":
+			return createListCommand(positional[1:], flags)
+		case "rename":
+			return renameListCommand(positional[1:], flags)
+		case "theme":
+			return listThemeCommand(positional[1:], flags)
+		case "delete", "rm":
+			return deleteListCommand(positional[1:], flags)
+		default:
+			fmt.Fprintf(os.Stderr, "Unknown lists command: %s\n", positional[0])
+			fmt.Fprintln(os.Stderr, "Usage: brings lists [create|rename|theme|delete]")
+			return 1
+		}
+	}
+
 	client, _, ok := getBringClient()
 	if !ok {
 		return 1
 	}
 	lists, err := client.LoadLists(context.Background())
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-		return 1
+		return reportError(err)
 	}
-	fmt.Println("Shopping Lists:")
-	fmt.Println()
+	output := listsOutput{Lists: []listOutput{}}
 	for _, list := range lists.Lists {
-		fmt.Printf("  %s (%s)\n", list.Name, list.ListUUID)
+		output.Lists = append(output.Lists, listOutput{ListUUID: list.ListUUID, Name: list.Name, Theme: list.Theme})
 	}
-	return 0
+	return outputFor(flags).print(output, func() {
+		fmt.Println("Shopping Lists:")
+		fmt.Println()
+		for _, list := range output.Lists {
+			fmt.Printf("  %s (%s)\n", list.Name, list.ListUUID)
+		}
+	})
 }
 
-func itemsCommand(positional []string, flags FlagSet) int {
-	client, _, ok := getBringClient()
-	if !ok {
+func createListCommand(positional []string, flags FlagSet) int {
+	if len(positional) == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: brings lists create <name> [--theme <theme>]")
 		return 1
 	}
-	listUUID, listName, err := getListUUID(client, flags.Get("list"))
+	theme, err := listTheme(flags.Get("theme"))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
 		return 1
 	}
-	if flags.Get("list") == "" {
-		fmt.Printf("List: %s\n\n", listName)
-	}
-
-	items, err := client.GetItems(context.Background(), listUUID)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+	client, _, ok := getBringClient()
+	if !ok {
 		return 1
 	}
-
-	if len(items.Purchase) == 0 && len(items.Recently) == 0 {
-		fmt.Println("Shopping list is empty")
-		return 0
-	}
-
-	if len(items.Purchase) > 0 {
-		fmt.Println("To Purchase:")
-		for _, item := range items.Purchase {
-			spec := ""
-			if item.Specification != "" {
-				spec = fmt.Sprintf(" (%s)", item.Specification)
-			}
-			fmt.Printf("  - %s%s\n", item.Name, spec)
-		}
-	}
-
-	if flags.Has("all") && len(items.Recently) > 0 {
-		fmt.Println("\nRecent Items:")
-		for _, item := range items.Recently {
-			fmt.Printf("  - %s\n", item.Name)
-		}
-	}
-	_ = positional
-	return 0
+	name := strings.Join(positional, " ")
+	listUUID, err := client.CreateList(context.Background(), name, theme)
+	if err != nil {
+		return reportError(err)
+	}
+	return outputFor(flags).result(result{
+		Action:   "list.create",
+		ListUUID: listUUID,
+		List:     name,
+		Theme:    theme,
+		Message:  fmt.Sprintf("Created list \"%s\" (%s)", name, listUUID),
+	})
 }
 
-func addCommand(positional []string, flags FlagSet) int {
-	client, _, ok := getBringClient()
-	if !ok {
+func renameListCommand(positional []string, flags FlagSet) int {
+	if len(positional) < 2 {
+		fmt.Fprintln(os.Stderr, "Usage: brings lists rename <list> <new name>")
 		return 1
 	}
-	if len(positional) == 0 {
-		fmt.Fprintln(os.Stderr, "Usage: brings add <item> [--spec \"specification\"] [--list <uuid>]")
+	client, _, ok := getBringClient()
+	if !ok {
 		return 1
 	}
-	itemName := positional[0]
-	spec := flags.Get("spec")
-
-	listUUID, listName, err := getListUUID(client, flags.Get("list"))
+	list, err := findList(client, positional[0])
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		return reportError(err)
+	}
+	name := strings.Join(positional[1:], " ")
+	if _, err := client.RenameList(context.Background(), list.ListUUID, name); err != nil {
+		return reportError(err)
+	}
+	return outputFor(flags).result(result{
+		Action:   "list.rename",
+		ListUUID: list.ListUUID,
+		List:     name,
+		Previous: list.Name,
+		Message:  fmt.Sprintf("Renamed \"%s\" to \"%s\"", list.Name, name),
+	})
+}
+
+func listThemeCommand(positional []string, flags FlagSet) int {
+	if len(positional) < 2 {
+		fmt.Fprintln(os.Stderr, "Usage: brings lists theme <list> <theme>")
+		fmt.Fprintln(os.Stderr, "Themes: home, bbq, office, holiday, party")
 		return 1
 	}
-	if _, err := client.SaveItem(context.Background(), listUUID, itemName, spec); err != nil {
+	theme, err := listTheme(positional[1])
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
 		return 1
 	}
-	if spec != "" {
-		fmt.Printf("Added \"%s\" (%s) to %s\n", itemName, spec, listName)
-	} else {
-		fmt.Printf("Added \"%s\" to %s\n", itemName, listName)
-	}
-	return 0
-}
-
-func removeCommand(positional []string, flags FlagSet) int {
 	client, _, ok := getBringClient()
 	if !ok {
 		return 1
 	}
-	if len(positional) == 0 {
-		fmt.Fprintln(os.Stderr, "Usage: brings remove <item> [--list <uuid>]")
-		return 1
-	}
-	itemName := positional[0]
-	listUUID, listName, err := getListUUID(client, flags.Get("list"))
+	list, err := findList(client, positional[0])
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-		return 1
+		return reportError(err)
 	}
-	if _, err := client.RemoveItem(context.Background(), listUUID, itemName); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-		return 1
+	if _, err := client.SetListTheme(context.Background(), list.ListUUID, theme); err != nil {
+		return reportError(err)
 	}
-	fmt.Printf("Removed \"%s\" from %s\n", itemName, listName)
-	return 0
+	return outputFor(flags).result(result{
+		Action:   "list.theme",
+		ListUUID: list.ListUUID,
+		List:     list.Name,
+		Theme:    theme,
+		Message:  fmt.Sprintf("Set theme of \"%s\" to %s", list.Name, theme),
+	})
 }
 
-func completeCommand(positional []string, flags FlagSet) int {
-	client, _, ok := getBringClient()
-	if !ok {
+func deleteListCommand(positional []string, flags FlagSet) int {
+	if len(positional) == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: brings lists delete <list> [--yes]")
 		return 1
 	}
-	if len(positional) == 0 {
-		fmt.Fprintln(os.Stderr, "Usage: brings complete <item> [--list <uuid>]")
+	client, cfg, ok := getBringClient()
+	if !ok {
 		return 1
 	}
-	itemName := positional[0]
-	listUUID, listName, err := getListUUID(client, flags.Get("list"))
+	list, err := findList(client, positional[0])
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-		return 1
+		return reportError(err)
 	}
-	if _, err := client.MoveToRecentList(context.Background(), listUUID, itemName); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-		return 1
+	if !flags.Has("yes") {
+		answer, err := prompt(fmt.Sprintf("Delete list \"%s\" and all of its items? [y/N] ", list.Name))
+		if err != nil || (!strings.EqualFold(answer, "y") && !strings.EqualFold(answer, "yes")) {
+			fmt.Fprintln(os.Stderr, "Aborted.")
+			return 1
+		}
 	}
-	fmt.Printf("Completed \"%s\" in %s\n", itemName, listName)
-	return 0
+	if _, err := client.DeleteList(context.Background(), list.ListUUID); err != nil {
+		return reportError(err)
+	}
+	if cfg.DefaultList == list.ListUUID || strings.EqualFold(cfg.DefaultList, list.Name) {
+		// Reload so that tokens refreshed during the requests are kept.
+		saved := loadConfig()
+		saved.DefaultList = ""
+		if err := saveConfig(saved); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not clear default list: %s\n", err)
+		}
+	}
+	return outputFor(flags).result(result{
+		Action:   "list.delete",
+		ListUUID: list.ListUUID,
+		List:     list.Name,
+		Message:  fmt.Sprintf("Deleted list \"%s\"", list.Name),
+	})
 }
 
-func activityCommand(flags FlagSet) int {
-	client, _, ok := getBringClient()
+// listThemes are the themes accepted by lists create and lists theme.
+var listThemes = []string{bring.ThemeHome, bring.ThemeBBQ, bring.ThemeOffice, bring.ThemeHoliday, bring.ThemeParty}
+
+// listTheme expands short theme names such as "bbq" to full theme IDs and
+// rejects themes the Bring! apps do not know.
+func listTheme(name string) (string, error) {
+	theme := name
+	if !strings.Contains(name, ".") {
+		theme = "ch.publisheria.bring.theme." + strings.ToLower(name)
+	}
+	if !slices.Contains(listThemes, theme) {
+		return "", fmt.Errorf("unknown theme %q (use home, bbq, office, holiday or party)", name)
+	}
+	return theme, nil
+}
+
+func itemsCommand(positional []string, flags FlagSet) int {
+	client, cfg, ok := getBringClient()
 	if !ok {
 		return 1
 	}
 	listUUID, listName, err := getListUUID(client, flags.Get("list"))
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-		return 1
+		return reportError(err)
 	}
-	fmt.Printf("Activity for: %s\n\n", listName)
 
-	activity, err := client.GetActivity(context.Background(), listUUID)
+	items, err := client.GetListItems(context.Background(), listUUID)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-		return 1
+		return reportError(err)
 	}
 
-	if len(activity.Timeline) == 0 {
-		fmt.Println("No recent activity")
-		return 0
+	// Members are only needed to name assignees or to resolve --assignee.
+	// Without a filter, failing to load them just leaves the names out.
+	var members []bring.GetAllUsersFromListEntry
+	if flags.Get("assignee") != "" || anyAssigned(items) {
+		users, err := client.GetAllUsersFromList(context.Background(), listUUID)
+		if err != nil && flags.Get("assignee") != "" {
+			return reportError(err)
+		}
+		members = users.Users
+	}
+	names := map[string]string{}
+	for _, member := range members {
+		names[member.PublicUUID] = coalesce(member.Name, member.Email)
 	}
 
-	for i, event := range activity.Timeline {
-		if i >= 10 {
-			break
+	assignee := ""
+	switch {
+	case flags.Has("mine"):
+		if assignee, err = currentUserUUID(client, cfg); err != nil {
+			return reportError(err)
 		}
-		ts := toString(event["timestamp"])
-		if ts == "" {
-			ts = toString(event["date"])
-		}
-		date := ts
-		if parsed, err := time.Parse(time.RFC3339, ts); err == nil {
-			date = parsed.Local().Format(time.RFC1123)
+	case flags.Get("assignee") != "":
+		member, err := findListUser(members, flags.Get("assignee"), listName)
+		if err != nil {
+			return reportError(err)
 		}
-		etype := coalesce(toString(event["type"]), toString(event["action"]))
-		content := ""
-		if item, ok := event["content"].(map[string]interface{}); ok {
-			content = coalesce(toString(item["itemId"]), toString(item["itemName"]))
+		assignee = member.PublicUUID
+	}
+
+	output := itemsOutput{ListUUID: listUUID, List: listName, Purchase: []itemOutput{}}
+	for i, item := range items {
+		if assignee != "" && item.AssignedTo != assignee {
+			continue
 		}
-		if content == "" {
-			content = coalesce(toString(event["itemId"]), toString(event["itemName"]))
+		entry := itemOutput{ListItem: item, Assignee: names[item.AssignedTo]}
+		if item.Status == bring.BringItemToPurchase {
+			entry.Index = i + 1
+			output.Purchase = append(output.Purchase, entry)
+		} else if flags.Has("all") {
+			output.Recently = append(output.Recently, entry)
 		}
-		fmt.Printf("  [%s] %s: %s\n", date, etype, content)
 	}
 
-	fmt.Printf("\nTotal events: %d\n", activity.TotalEvents)
-	return 0
+	return outputFor(flags).print(output, func() {
+		// The header names the list only when it was not chosen explicitly.
+		if flags.Get("list") == "" {
+			fmt.Printf("List: %s\n\n", output.List)
+		}
+		if len(output.Purchase) == 0 && len(output.Recently) == 0 {
+			if assignee != "" {
+				fmt.Printf("No items assigned to %s\n", coalesce(names[assignee], "you"))
+			} else {
+				fmt.Println("Shopping list is empty")
+			}
+			return
+		}
+
+		if len(output.Purchase) > 0 {
+			fmt.Println("To Purchase:")
+			for _, item := range output.Purchase {
+				fmt.Printf("  %d. %s%s%s\n", item.Index, item, attributesSuffix(item.Attributes), detailsSuffix(item))
+			}
+		}
+
+		if len(output.Recently) > 0 {
+			fmt.Println("\nRecent Items:")
+			for _, item := range output.Recently {
+				fmt.Printf("  - %s\n", item.ItemID)
+			}
+		}
+	})
 }
 
-func usersCommand(flags FlagSet) int {
-	client, _, ok := getBringClient()
-	if !ok {
-		return 1
+func anyAssigned(items []bring.ListItem) bool {
+	for _, item := range items {
+		if item.AssignedTo != "" {
+			return true
+		}
 	}
-	listUUID, listName, err := getListUUID(client, flags.Get("list"))
+	return false
+}
+
+// currentUserUUID returns the public UUID of the logged-in user, asking the
+// account endpoint when the config predates it being saved.
+func currentUserUUID(client *bring.Bring, cfg Config) (string, error) {
+	if uuid := coalesce(cfg.PublicUserUUID, client.PublicUserUUID()); uuid != "" {
+		return uuid, nil
+	}
+	account, err := client.GetUserAccount(context.Background())
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-		return 1
+		return "", err
 	}
-	fmt.Printf("Users in: %s\n\n", listName)
+	return account.PublicUserUUID, nil
+}
 
-	users, err := client.GetAllUsersFromList(context.Background(), listUUID)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-		return 1
+// detailsSuffix describes an item's assignee, section and photo, if any.
+func detailsSuffix(item itemOutput) string {
+	var parts []string
+	if item.AssignedTo != "" {
+		parts = append(parts, "assigned to "+coalesce(item.Assignee, item.AssignedTo))
 	}
-	for _, user := range users.Users {
-		fmt.Printf("  - %s (%s)\n", user.Name, user.Email)
+	if item.SectionID != "" {
+		parts = append(parts, "section "+item.SectionID)
 	}
-	return 0
+	if item.ImageURL != "" {
+		parts = append(parts, "photo")
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " - " + strings.Join(parts, ", ")
 }
 
-func accountCommand() int {
-	client, _, ok := getBringClient()
-	if !ok {
+// watchCommand prints the changes of a list's items to purchase until
+// interrupted, one line per change; in JSON modes as newline-delimited JSON.
+func watchCommand(positional []string, flags FlagSet) int {
+	if len(positional) > 0 {
+		fmt.Fprintln(os.Stderr, "Usage: brings watch [--list <list>] [--interval 10s] [--count <n>]")
 		return 1
 	}
-	account, err := client.GetUserAccount(context.Background())
+	interval, err := parseInterval(flags.Get("interval"))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
 		return 1
 	}
-
-	fmt.Println("Account Information:")
-	fmt.Println()
-	fmt.Printf("  Name: %s\n", coalesce(account.Name, "N/A"))
-	fmt.Printf("  Email: %s\n", account.Email)
-	if account.EmailVerified {
-		fmt.Println("  Email Verified: Yes")
-	} else {
-		fmt.Println("  Email Verified: No")
+	count, _ := strconv.Atoi(flags.Get("count"))
+	client, _, ok := getBringClient()
+	if !ok {
+		return 1
 	}
-	locale := account.UserLocale.String()
-	if locale == "" {
-		locale = "N/A"
+	listUUID, listName, err := getListUUID(client, flags.Get("list"))
+	if err != nil {
+		return reportError(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// Errors that polling again cannot fix end the watch; others are
+	// reported and retried on the next poll.
+	var fatal error
+	changes := bring.Watch(ctx, client, listUUID, bring.WatchOptions{
+		Interval: interval,
+		OnError: func(err error) {
+			if errors.Is(err, bring.ErrUnauthorized) || errors.Is(err, bring.ErrNotFound) {
+				fatal = err
+				cancel()
+				return
+			}
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		},
+	})
+
+	out := outputFor(flags)
+	out.progress("Watching %s every %s (Ctrl-C to stop)\n", listName, interval)
+	seen := 0
+	for change := range changes {
+		if out.json {
+			printJSON(change, out.pretty)
+		} else {
+			fmt.Println(describeChange(change))
+		}
+		if seen++; count > 0 && seen >= count {
+			cancel()
+		}
+	}
+	if fatal != nil {
+		return reportError(fatal)
 	}
-	fmt.Printf("  Locale: %s\n", locale)
-	fmt.Printf("  User UUID: %s\n", account.UserUUID)
-	fmt.Printf("  Public UUID: %s\n", account.PublicUserUUID)
 	return 0
 }
 
-func settingsCommand() int {
+// parseInterval parses a duration such as "30s" or "2m", or a number of
+// seconds.
+func parseInterval(value string) (time.Duration, error) {
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		seconds, convErr := strconv.Atoi(value)
+		if convErr != nil {
+			return 0, fmt.Errorf("invalid interval %q (use e.g. 30s or 2m)", value)
+		}
+		interval = time.Duration(seconds) * time.Second
+	}
+	if interval <= 0 {
+		return 0, fmt.Errorf("invalid interval %q (must be positive)", value)
+	}
+	return interval, nil
+}
+
+// describeChange formats a list change as a line for human output.
+func describeChange(change bring.ListChange) string {
+	at := change.Time.Format("15:04:05")
+	switch change.Type {
+	case bring.ChangeSpecChanged:
+		return fmt.Sprintf("%s spec changed %s: %q -> %q", at, change.Item.ItemID, change.PreviousSpec, change.Item.Spec)
+	case bring.ChangeCompleted:
+		return fmt.Sprintf("%s completed %s", at, change.Item)
+	case bring.ChangeRemoved:
+		return fmt.Sprintf("%s removed %s", at, change.Item)
+	default:
+		return fmt.Sprintf("%s added %s", at, change.Item)
+	}
+}
+
+func addCommand(positional []string, flags FlagSet) int {
+	args, err := itemArgs(positional)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		return 1
+	}
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: brings add <item>[:spec]... | - [--spec \"specification\"] [--list <list>]")
+		return 1
+	}
 	client, _, ok := getBringClient()
 	if !ok {
 		return 1
 	}
-	settings, err := client.GetUserSettings(context.Background())
+	attrs, flagged := attributeFlags(flags, bring.ItemAttributes{})
+
+	listUUID, listName, err := getListUUID(client, flags.Get("list"))
+	if err != nil {
+		return reportError(err)
+	}
+
+	added := result{Action: "add", ListUUID: listUUID, List: listName}
+	changes := []bring.BatchUpdateItem{}
+	names := []string{}
+	for _, arg := range args {
+		name, spec := splitItemSpec(arg, flags.Get("spec"))
+		added.Results = append(added.Results, itemOutcome{Item: name, Spec: spec})
+		changes = append(changes, bring.BatchUpdateItem{ItemID: name, Spec: spec, Operation: bring.BringItemToPurchase})
+		if flagged {
+			changes = append(changes, attrs.Update(name))
+		}
+		names = append(names, bring.ListItem{ItemID: name, Spec: spec}.String())
+	}
+	if _, err := client.BatchUpdateItems(context.Background(), listUUID, changes, bring.BringItemToPurchase); err != nil {
+		return reportError(err)
+	}
+
+	if flagged {
+		added.Attributes = &attrs
+	}
+	if len(args) == 1 {
+		added.Item, added.Spec = added.Results[0].Item, added.Results[0].Spec
+		added.Message = fmt.Sprintf("Added \"%s\" to %s%s", added.Item, listName, attributesSuffix(attrs))
+		if added.Spec != "" {
+			added.Message = fmt.Sprintf("Added \"%s\" (%s) to %s%s", added.Item, added.Spec, listName, attributesSuffix(attrs))
+		}
+	} else {
+		added.Message = fmt.Sprintf("Added %d items to %s%s: %s", len(names), listName, attributesSuffix(attrs), strings.Join(names, ", "))
+	}
+	return outputFor(flags).result(added)
+}
+
+// itemArgs returns the item arguments of add, remove and complete. "-"
+// stands for the lines read from stdin, one item per line.
+func itemArgs(positional []string) ([]string, error) {
+	args := []string{}
+	for _, arg := range positional {
+		if arg != "-" {
+			args = append(args, arg)
+			continue
+		}
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" {
+				args = append(args, line)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("cannot read items from stdin: %w", err)
+		}
+	}
+	return args, nil
+}
+
+// splitItemSpec splits "Bread:whole grain" into name and spec. Without an
+// inline spec, the spec is defaultSpec.
+func splitItemSpec(arg, defaultSpec string) (string, string) {
+	if name, spec, found := strings.Cut(arg, ":"); found {
+		return strings.TrimSpace(name), strings.TrimSpace(spec)
+	}
+	return arg, defaultSpec
+}
+
+func flagCommand(positional []string, flags FlagSet) int {
+	if len(positional) == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: brings flag <item> [--urgent[=false]] [--convenient[=false]] [--discounted[=false]] [--list <list>]")
+		return 1
+	}
+	client, _, ok := getBringClient()
+	if !ok {
+		return 1
+	}
+	itemName := positional[0]
+	listUUID, listName, err := getListUUID(client, flags.Get("list"))
+	if err != nil {
+		return reportError(err)
+	}
+	item, err := findItem(client, listUUID, listName, itemName, flags.Get("spec"), outputFor(flags), true)
+	if err != nil {
+		return reportError(err)
+	}
+	if item.Status != bring.BringItemToPurchase {
+		return reportError(notFoundError{fmt.Sprintf("%q is not to purchase on %s", itemName, listName)})
+	}
+
+	attrs, changed := attributeFlags(flags, item.Attributes)
+	if !changed {
+		fmt.Fprintln(os.Stderr, "Nothing to change: pass --urgent, --convenient or --discounted (=true|false)")
+		return 1
+	}
+	if _, err := client.SetItemAttributes(context.Background(), listUUID, item.ItemID, attrs); err != nil {
+		return reportError(err)
+	}
+	return outputFor(flags).result(result{
+		Action:     "flag",
+		ListUUID:   listUUID,
+		List:       listName,
+		Item:       item.ItemID,
+		ItemUUID:   item.UUID,
+		Attributes: &attrs,
+		Message:    fmt.Sprintf("Flagged \"%s\" in %s: %s", item.ItemID, listName, coalesce(attrs.String(), "no attributes")),
+	})
+}
+
+func editCommand(positional []string, flags FlagSet) int {
+	spec, specSet := flags.Values["spec"]
+	newName := flags.Get("name")
+	if len(positional) == 0 || (!specSet && newName == "") || (flags.Has("append") && spec == "") {
+		fmt.Fprintln(os.Stderr, "Usage: brings edit <item|index|uuid> --spec <text> [--append] [--name <new name>] [--list <list>]")
+		return 1
+	}
+	client, _, ok := getBringClient()
+	if !ok {
+		return 1
+	}
+	listUUID, listName, err := getListUUID(client, flags.Get("list"))
+	if err != nil {
+		return reportError(err)
+	}
+	out := outputFor(flags)
+	item, err := findItem(client, listUUID, listName, positional[0], "", out, true)
+	if err != nil {
+		return reportError(err)
+	}
+	if item.Status != bring.BringItemToPurchase {
+		return reportError(notFoundError{fmt.Sprintf("%q is not to purchase on %s", positional[0], listName)})
+	}
+
+	switch {
+	case !specSet:
+		spec = item.Spec
+	case flags.Has("append") && item.Spec != "":
+		spec = item.Spec + ", " + spec
+	}
+	edited := bring.ListItem{ItemID: coalesce(newName, item.ItemID), Spec: spec}
+	if edited.ItemID == item.ItemID {
+		edited.UUID = item.UUID
+		_, err = client.UpdateItem(context.Background(), listUUID, item.ItemID, spec, item.UUID)
+	} else {
+		_, err = client.ReplaceItem(context.Background(), listUUID, item, edited.ItemID, spec)
+	}
+	if err != nil {
+		return reportError(err)
+	}
+	return out.result(result{
+		Action:   "edit",
+		ListUUID: listUUID,
+		List:     listName,
+		Previous: item.String(),
+		Item:     edited.ItemID,
+		ItemUUID: edited.UUID,
+		Spec:     spec,
+		Message:  fmt.Sprintf("Changed \"%s\" to \"%s\" in %s", item, edited, listName),
+	})
+}
+
+func imageCommand(positional []string, flags FlagSet) int {
+	usage := "Usage: brings image set <item> <file.jpg|file.png> | brings image rm <item> [--list <list>]"
+	if len(positional) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		return 1
+	}
+	action := positional[0]
+	if (action != "set" && action != "rm" && action != "remove") || (action == "set" && len(positional) < 3) {
+		fmt.Fprintln(os.Stderr, usage)
+		return 1
+	}
+	client, _, ok := getBringClient()
+	if !ok {
+		return 1
+	}
+	listUUID, listName, err := getListUUID(client, flags.Get("list"))
+	if err != nil {
+		return reportError(err)
+	}
+	item, err := findItem(client, listUUID, listName, positional[1], flags.Get("spec"), outputFor(flags), true)
+	if err == nil {
+		err = requireItemUUID(item)
+	}
+	if err != nil {
+		return reportError(err)
+	}
+	itemUUID, itemName := item.UUID, item.ItemID
+
+	if action == "set" {
+		file, err := os.Open(positional[2])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+			return 1
+		}
+		defer file.Close()
+		saved, err := client.SaveItemImageFrom(context.Background(), itemUUID, file)
+		if err != nil {
+			return reportError(err)
+		}
+		return outputFor(flags).result(result{
+			Action:   "image.set",
+			ListUUID: listUUID,
+			List:     listName,
+			Item:     itemName,
+			ItemUUID: itemUUID,
+			ImageURL: saved["imageUrl"],
+			Message:  fmt.Sprintf("Set image of \"%s\" in %s", itemName, listName),
+		})
+	}
+
+	if _, err := client.RemoveItemImage(context.Background(), itemUUID); err != nil {
+		return reportError(err)
+	}
+	return outputFor(flags).result(result{
+		Action:   "image.remove",
+		ListUUID: listUUID,
+		List:     listName,
+		Item:     itemName,
+		ItemUUID: itemUUID,
+		Message:  fmt.Sprintf("Removed image of \"%s\" in %s", itemName, listName),
+	})
+}
+
+func assignCommand(positional []string, flags FlagSet) int {
+	usage := "Usage: brings assign <item> <user name|email> | brings assign <item> --clear [--list <list>]"
+	if len(positional) == 0 || (len(positional) < 2 && !flags.Has("clear")) {
+		fmt.Fprintln(os.Stderr, usage)
+		return 1
+	}
+	client, _, ok := getBringClient()
+	if !ok {
+		return 1
+	}
+	listUUID, listName, err := getListUUID(client, flags.Get("list"))
+	if err != nil {
+		return reportError(err)
+	}
+	// Only items still to buy can be assigned.
+	item, err := findItem(client, listUUID, listName, positional[0], flags.Get("spec"), outputFor(flags), false)
+	if err == nil {
+		err = requireItemUUID(item)
+	}
+	if err != nil {
+		return reportError(err)
+	}
+	itemUUID, itemName := item.UUID, item.ItemID
+
+	if flags.Has("clear") {
+		if _, err := client.AssignItem(context.Background(), itemUUID, ""); err != nil {
+			return reportError(err)
+		}
+		return outputFor(flags).result(result{
+			Action:   "unassign",
+			ListUUID: listUUID,
+			List:     listName,
+			Item:     itemName,
+			ItemUUID: itemUUID,
+			Message:  fmt.Sprintf("Unassigned \"%s\" in %s", itemName, listName),
+		})
+	}
+
+	users, err := client.GetAllUsersFromList(context.Background(), listUUID)
+	if err != nil {
+		return reportError(err)
+	}
+	user, err := findListUser(users.Users, positional[1], listName)
+	if err != nil {
+		return reportError(err)
+	}
+	if _, err := client.AssignItem(context.Background(), itemUUID, user.PublicUUID); err != nil {
+		return reportError(err)
+	}
+	return outputFor(flags).result(result{
+		Action:   "assign",
+		ListUUID: listUUID,
+		List:     listName,
+		Item:     itemName,
+		ItemUUID: itemUUID,
+		User:     coalesce(user.Name, user.Email),
+		UserUUID: user.PublicUUID,
+		Message:  fmt.Sprintf("Assigned \"%s\" to %s in %s", itemName, coalesce(user.Name, user.Email), listName),
+	})
+}
+
+// findListUser matches a list member by email, name or public UUID, ignoring
+// case. A name shared by several members is rejected as ambiguous.
+func findListUser(users []bring.GetAllUsersFromListEntry, arg, listName string) (bring.GetAllUsersFromListEntry, error) {
+	var matches []bring.GetAllUsersFromListEntry
+	for _, user := range users {
+		if user.PublicUUID == arg || (user.Email != "" && strings.EqualFold(user.Email, arg)) {
+			return user, nil
+		}
+		if strings.EqualFold(user.Name, arg) {
+			matches = append(matches, user)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return bring.GetAllUsersFromListEntry{}, notFoundError{fmt.Sprintf("no member %q in %s", arg, listName)}
+	case 1:
+		return matches[0], nil
+	}
+	emails := make([]string, 0, len(matches))
+	for _, user := range matches {
+		emails = append(emails, coalesce(user.Email, user.PublicUUID))
+	}
+	return bring.GetAllUsersFromListEntry{}, fmt.Errorf("%q matches several members of %s, use the email instead: %s", arg, listName, strings.Join(emails, ", "))
+}
+
+// findItem resolves an item argument on a list, see pickItem. Recently
+// purchased items are only considered if includeRecent is set.
+func findItem(client *bring.Bring, listUUID, listName, arg, spec string, out output, includeRecent bool) (bring.ListItem, error) {
+	items, err := client.GetListItems(context.Background(), listUUID)
+	if err != nil {
+		return bring.ListItem{}, err
+	}
+	if !includeRecent {
+		items = purchaseItems(items)
+	}
+	i, err := pickItem(items, arg, spec, listName, out)
+	if err != nil {
+		return bring.ListItem{}, err
+	}
+	return items[i], nil
+}
+
+// purchaseItems returns the items still to buy. They come first in
+// GetListItems, so their indices stay the same.
+func purchaseItems(items []bring.ListItem) []bring.ListItem {
+	purchase := []bring.ListItem{}
+	for _, item := range items {
+		if item.Status == bring.BringItemToPurchase {
+			purchase = append(purchase, item)
+		}
+	}
+	return purchase
+}
+
+// pickItem returns the position of the single item arg refers to, see
+// matchItems. When several items match, it asks which one is meant if stdin
+// is a terminal and out is human, and fails listing the candidates otherwise.
+func pickItem(items []bring.ListItem, arg, spec, listName string, out output) (int, error) {
+	matches := matchItems(items, arg, spec)
+	switch len(matches) {
+	case 0:
+		return 0, itemNotFound(arg, spec, listName)
+	case 1:
+		return matches[0], nil
+	}
+
+	candidates := make([]string, 0, len(matches))
+	for _, i := range matches {
+		candidates = append(candidates, itemReference(items, i))
+	}
+	if out.json || !stdinIsTerminal() {
+		return 0, fmt.Errorf("%q matches several items on %s, use the index, UUID or --spec: %s", arg, listName, strings.Join(candidates, ", "))
+	}
+	fmt.Fprintf(os.Stderr, "%q matches several items on %s:\n", arg, listName)
+	for n, candidate := range candidates {
+		fmt.Fprintf(os.Stderr, "  %d) %s\n", n+1, candidate)
+	}
+	answer, err := prompt(fmt.Sprintf("Which one? [1-%d] ", len(matches)))
+	if n, convErr := strconv.Atoi(answer); err == nil && convErr == nil && n >= 1 && n <= len(matches) {
+		return matches[n-1], nil
+	}
+	return 0, fmt.Errorf("no item chosen for %q", arg)
+}
+
+// requireItemUUID fails for items the API returned without a UUID, which
+// cannot be addressed by the item details endpoints.
+func requireItemUUID(item bring.ListItem) error {
+	if item.UUID == "" {
+		return fmt.Errorf("item %q has no UUID, try again after `brings items` shows it", item.ItemID)
+	}
+	return nil
+}
+
+func itemNotFound(arg, spec, listName string) error {
+	if spec != "" {
+		return notFoundError{fmt.Sprintf("%q (%s) is not on %s", arg, spec, listName)}
+	}
+	return notFoundError{fmt.Sprintf("%q is not on %s", arg, listName)}
+}
+
+// matchItems returns the positions in items of the items arg refers to. arg
+// is the index of an item to purchase as shown by `brings items`, an item
+// UUID or a name, optionally narrowed down by spec. Names match items to
+// purchase before recent ones.
+func matchItems(items []bring.ListItem, arg, spec string) []int {
+	if n, err := strconv.Atoi(arg); err == nil && n >= 1 && n <= len(items) && items[n-1].Status == bring.BringItemToPurchase {
+		return []int{n - 1}
+	}
+	for i, item := range items {
+		if item.UUID != "" && item.UUID == arg {
+			return []int{i}
+		}
+	}
+	for _, status := range []bring.BringItemOperation{bring.BringItemToPurchase, bring.BringItemToRecently} {
+		var matches []int
+		for i, item := range items {
+			if item.Status == status && strings.EqualFold(item.ItemID, arg) && (spec == "" || strings.EqualFold(item.Spec, spec)) {
+				matches = append(matches, i)
+			}
+		}
+		if len(matches) > 0 {
+			return matches
+		}
+	}
+	return nil
+}
+
+// itemReference describes the item at position i for disambiguation: items
+// to purchase by index, recent ones by UUID.
+func itemReference(items []bring.ListItem, i int) string {
+	if items[i].Status == bring.BringItemToPurchase {
+		return fmt.Sprintf("%d. %s", i+1, items[i])
+	}
+	return fmt.Sprintf("%s [%s]", items[i], items[i].UUID)
+}
+
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// attributeFlags applies --urgent, --convenient and --discounted to current.
+// It reports whether any of them was given.
+func attributeFlags(flags FlagSet, current bring.ItemAttributes) (bring.ItemAttributes, bool) {
+	attrs := current
+	changed := false
+	for _, flag := range []struct {
+		name   string
+		target *bool
+	}{
+		{"urgent", &attrs.Urgent},
+		{"convenient", &attrs.Convenient},
+		{"discounted", &attrs.Discounted},
+	} {
+		if value, set := flags.Bools[flag.name]; set {
+			*flag.target = value
+			changed = true
+		}
+	}
+	return attrs, changed
+}
+
+func attributesSuffix(attrs bring.ItemAttributes) string {
+	if attrs.IsZero() {
+		return ""
+	}
+	return " [" + attrs.String() + "]"
+}
+
+func removeCommand(positional []string, flags FlagSet) int {
+	return changeItemsCommand(positional, flags, "remove", bring.BringItemRemove)
+}
+
+func completeCommand(positional []string, flags FlagSet) int {
+	return changeItemsCommand(positional, flags, "complete", bring.BringItemToRecently)
+}
+
+// changeItemsCommand removes or completes the items named by positional in
+// a single batch update. Items that cannot be resolved are reported and left
+// out; the others are still changed.
+func changeItemsCommand(positional []string, flags FlagSet, action string, operation bring.BringItemOperation) int {
+	args, err := itemArgs(positional)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
 		return 1
 	}
-
-	fmt.Println("User Settings:")
-	fmt.Println()
-	for _, setting := range settings.UserSettings {
-		fmt.Printf("  %s: %s\n", setting.Key, setting.Value)
+	if len(args) == 0 {
+		fmt.Fprintf(os.Stderr, "Usage: brings %s <item|index|uuid>... | - [--spec <text>] [--all-matching] [--list <list>]\n", action)
+		return 1
+	}
+	client, _, ok := getBringClient()
+	if !ok {
+		return 1
+	}
+	listUUID, listName, err := getListUUID(client, flags.Get("list"))
+	if err != nil {
+		return reportError(err)
+	}
+	items, err := client.GetListItems(context.Background(), listUUID)
+	if err != nil {
+		return reportError(err)
+	}
+	if operation == bring.BringItemToRecently {
+		// Recently purchased items are already complete.
+		items = purchaseItems(items)
+	}
+
+	out := outputFor(flags)
+	changed := result{Action: action, ListUUID: listUUID, List: listName}
+	changes := []bring.BatchUpdateItem{}
+	names := []string{}
+	seen := map[int]bool{}
+	exitCode := 0
+	for _, arg := range args {
+		name, spec := splitItemSpec(arg, flags.Get("spec"))
+		var positions []int
+		if flags.Has("all-matching") {
+			if positions = matchItems(items, name, spec); len(positions) == 0 {
+				err = itemNotFound(name, spec, listName)
+			}
+		} else {
+			var position int
+			position, err = pickItem(items, name, spec, listName, out)
+			positions = []int{position}
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+			changed.Results = append(changed.Results, itemOutcome{Item: name, Spec: spec, Error: err.Error()})
+			exitCode = failedItemExitCode(exitCode, err)
+			continue
+		}
+		for _, i := range positions {
+			if seen[i] {
+				continue
+			}
+			seen[i] = true
+			item := items[i]
+			changed.Results = append(changed.Results, itemOutcome{Item: item.ItemID, Spec: item.Spec, ItemUUID: item.UUID})
+			changes = append(changes, bring.BatchUpdateItem{ItemID: item.ItemID, Spec: item.Spec, UUID: item.UUID})
+			names = append(names, item.String())
+		}
+	}
+	if len(changes) == 0 {
+		if out.json {
+			changed.Message = fmt.Sprintf("Nothing to %s", action)
+			out.result(changed)
+		}
+		return exitCode
+	}
+	if _, err := client.BatchUpdateItems(context.Background(), listUUID, changes, operation); err != nil {
+		return reportError(err)
+	}
+
+	verb, preposition := "Completed", "in"
+	if action == "remove" {
+		verb, preposition = "Removed", "from"
+	}
+	if len(names) == 1 && len(changed.Results) == 1 {
+		changed.Item, changed.Spec, changed.ItemUUID = changes[0].ItemID, changes[0].Spec, changes[0].UUID
+		changed.Message = fmt.Sprintf("%s \"%s\" %s %s", verb, names[0], preposition, listName)
+	} else {
+		changed.Message = fmt.Sprintf("%s %d items %s %s: %s", verb, len(names), preposition, listName, strings.Join(names, ", "))
+	}
+	out.result(changed)
+	return exitCode
+}
+
+// failedItemExitCode returns the exit code after an item failed with err:
+// 4 if every failure so far was a missing item, 1 otherwise.
+func failedItemExitCode(current int, err error) int {
+	if !errors.Is(err, bring.ErrNotFound) {
+		return exitError
+	}
+	if current == 0 {
+		return exitNotFound
+	}
+	return current
+}
+
+func recentCommand(positional []string, flags FlagSet) int {
+	if len(positional) > 0 && positional[0] != "clear" {
+		fmt.Fprintln(os.Stderr, "Usage: brings recent [clear] [--yes] [--list <list>]")
+		return 1
+	}
+	client, _, ok := getBringClient()
+	if !ok {
+		return 1
+	}
+	listUUID, listName, err := getListUUID(client, flags.Get("list"))
+	if err != nil {
+		return reportError(err)
+	}
+	recent, err := recentItems(client, listUUID)
+	if err != nil {
+		return reportError(err)
+	}
+	out := outputFor(flags)
+
+	if len(positional) == 0 {
+		output := recentOutput{ListUUID: listUUID, List: listName, Recently: []itemOutput{}}
+		for i, item := range recent {
+			output.Recently = append(output.Recently, itemOutput{Index: i + 1, ListItem: item})
+		}
+		return out.print(output, func() {
+			if len(recent) == 0 {
+				fmt.Printf("No recently purchased items on %s\n", listName)
+				return
+			}
+			fmt.Printf("Recently purchased on %s:\n", listName)
+			for _, item := range output.Recently {
+				fmt.Printf("  %d. %s\n", item.Index, item)
+			}
+		})
+	}
+
+	cleared := result{Action: "recent.clear", ListUUID: listUUID, List: listName, Results: []itemOutcome{}}
+	if len(recent) == 0 {
+		cleared.Message = fmt.Sprintf("No recently purchased items on %s", listName)
+		return out.result(cleared)
+	}
+	if !flags.Has("yes") {
+		answer, err := prompt(fmt.Sprintf("Clear %d recently purchased items from \"%s\"? [y/N] ", len(recent), listName))
+		if err != nil || (!strings.EqualFold(answer, "y") && !strings.EqualFold(answer, "yes")) {
+			fmt.Fprintln(os.Stderr, "Aborted.")
+			return 1
+		}
+	}
+	changes := make([]bring.BatchUpdateItem, 0, len(recent))
+	for _, item := range recent {
+		changes = append(changes, bring.BatchUpdateItem{ItemID: item.ItemID, Spec: item.Spec, UUID: item.UUID})
+		cleared.Results = append(cleared.Results, itemOutcome{Item: item.ItemID, Spec: item.Spec, ItemUUID: item.UUID})
+	}
+	if _, err := client.BatchUpdateItems(context.Background(), listUUID, changes, bring.BringItemRemove); err != nil {
+		return reportError(err)
+	}
+	cleared.Message = fmt.Sprintf("Cleared %d recently purchased items from %s", len(recent), listName)
+	return out.result(cleared)
+}
+
+// readdCommand moves recent items back to the items to purchase, keeping
+// their specification, in a single batch update.
+func readdCommand(positional []string, flags FlagSet) int {
+	args, err := itemArgs(positional)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		return 1
+	}
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: brings readd <name|index|uuid>... | - [--list <list>]")
+		fmt.Fprintln(os.Stderr, "\nRun `brings recent` to see the indices")
+		return 1
+	}
+	client, _, ok := getBringClient()
+	if !ok {
+		return 1
+	}
+	listUUID, listName, err := getListUUID(client, flags.Get("list"))
+	if err != nil {
+		return reportError(err)
+	}
+	recent, err := recentItems(client, listUUID)
+	if err != nil {
+		return reportError(err)
+	}
+
+	readded := result{Action: "readd", ListUUID: listUUID, List: listName}
+	changes := []bring.BatchUpdateItem{}
+	names := []string{}
+	seen := map[int]bool{}
+	exitCode := 0
+	for _, arg := range args {
+		name, spec := splitItemSpec(arg, "")
+		i, err := matchRecent(recent, name, spec, listName)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+			readded.Results = append(readded.Results, itemOutcome{Item: name, Spec: spec, Error: err.Error()})
+			exitCode = failedItemExitCode(exitCode, err)
+			continue
+		}
+		if seen[i] {
+			continue
+		}
+		seen[i] = true
+		item := recent[i]
+		readded.Results = append(readded.Results, itemOutcome{Item: item.ItemID, Spec: item.Spec, ItemUUID: item.UUID})
+		changes = append(changes, bring.BatchUpdateItem{ItemID: item.ItemID, Spec: item.Spec, UUID: item.UUID})
+		names = append(names, item.String())
+	}
+	out := outputFor(flags)
+	if len(changes) == 0 {
+		if out.json {
+			readded.Message = "Nothing to re-add"
+			out.result(readded)
+		}
+		return exitCode
+	}
+	if _, err := client.BatchUpdateItems(context.Background(), listUUID, changes, bring.BringItemToPurchase); err != nil {
+		return reportError(err)
+	}
+
+	if len(names) == 1 && len(readded.Results) == 1 {
+		readded.Item, readded.Spec, readded.ItemUUID = changes[0].ItemID, changes[0].Spec, changes[0].UUID
+		readded.Message = fmt.Sprintf("Re-added \"%s\" to %s", names[0], listName)
+	} else {
+		readded.Message = fmt.Sprintf("Re-added %d items to %s: %s", len(names), listName, strings.Join(names, ", "))
+	}
+	out.result(readded)
+	return exitCode
+}
+
+// recentItems returns the recently purchased items of a list, most recent
+// first.
+func recentItems(client *bring.Bring, listUUID string) ([]bring.ListItem, error) {
+	items, err := client.GetListItems(context.Background(), listUUID)
+	if err != nil {
+		return nil, err
+	}
+	recent := []bring.ListItem{}
+	for _, item := range items {
+		if item.Status == bring.BringItemToRecently {
+			recent = append(recent, item)
+		}
+	}
+	return recent, nil
+}
+
+// matchRecent returns the position of the recent item arg refers to: its
+// index as shown by `brings recent`, its UUID or its name, optionally
+// narrowed down by spec.
+func matchRecent(recent []bring.ListItem, arg, spec, listName string) (int, error) {
+	if n, err := strconv.Atoi(arg); err == nil && n >= 1 && n <= len(recent) {
+		return n - 1, nil
+	}
+	var matches []int
+	for i, item := range recent {
+		if item.UUID != "" && item.UUID == arg {
+			return i, nil
+		}
+		if strings.EqualFold(item.ItemID, arg) && (spec == "" || strings.EqualFold(item.Spec, spec)) {
+			matches = append(matches, i)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		if spec != "" {
+			return 0, notFoundError{fmt.Sprintf("%q (%s) is not recently purchased on %s", arg, spec, listName)}
+		}
+		return 0, notFoundError{fmt.Sprintf("%q is not recently purchased on %s", arg, listName)}
+	case 1:
+		return matches[0], nil
+	}
+	candidates := make([]string, 0, len(matches))
+	for _, i := range matches {
+		candidates = append(candidates, fmt.Sprintf("%d. %s", i+1, recent[i]))
+	}
+	return 0, fmt.Errorf("%q matches several recent items on %s, use the index: %s", arg, listName, strings.Join(candidates, ", "))
+}
+
+func activityCommand(positional []string, flags FlagSet) int {
+	client, _, ok := getBringClient()
+	if !ok {
+		return 1
+	}
+	listUUID, listName, err := getListUUID(client, flags.Get("list"))
+	if err != nil {
+		return reportError(err)
+	}
+
+	activity, err := client.GetActivity(context.Background(), listUUID)
+	if err != nil {
+		return reportError(err)
+	}
+	output := activityOutput{
+		ListUUID:    listUUID,
+		List:        listName,
+		TotalEvents: activity.TotalEvents,
+		Events:      activity.Timeline,
+	}
+	if output.Events == nil {
+		output.Events = []bring.ActivityEvent{}
+	}
+	return outputFor(flags).print(output, func() {
+		fmt.Printf("Activity for: %s\n\n", listName)
+		if len(activity.Timeline) == 0 {
+			fmt.Println("No recent activity")
+			return
+		}
+
+		for i, event := range activity.Timeline {
+			if i >= 10 {
+				break
+			}
+			date := "unknown time"
+			if !event.Time.IsZero() {
+				date = event.Time.Local().Format(time.RFC1123)
+			}
+			etype := string(event.Type)
+			content := activityItemsSummary(event.Items)
+			fmt.Printf("  [%s] %s: %s\n", date, etype, content)
+		}
+
+		fmt.Printf("\nTotal events: %d\n", activity.TotalEvents)
+	})
+}
+
+func activityItemsSummary(items []bring.ActivityItem) string {
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		if item.Spec != "" {
+			names = append(names, fmt.Sprintf("%s (%s)", item.ItemID, item.Spec))
+		} else {
+			names = append(names, item.ItemID)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+func usersCommand(positional []string, flags FlagSet) int {
+	if len(positional) > 0 && positional[0] != "invite" {
+		fmt.Fprintf(os.Stderr, "Unknown users command: %s\n", positional[0])
+		fmt.Fprintln(os.Stderr, "Usage: brings users [invite <email>] [--list <list>]")
+		return 1
+	}
+	if len(positional) == 1 {
+		fmt.Fprintln(os.Stderr, "Usage: brings users invite <email> [--list <list>]")
+		return 1
+	}
+	client, _, ok := getBringClient()
+	if !ok {
+		return 1
+	}
+	listUUID, listName, err := getListUUID(client, flags.Get("list"))
+	if err != nil {
+		return reportError(err)
+	}
+
+	if len(positional) > 1 {
+		email := positional[1]
+		if _, err := client.InviteToList(context.Background(), listUUID, email); err != nil {
+			return reportError(err)
+		}
+		return outputFor(flags).result(result{
+			Action:   "invite",
+			ListUUID: listUUID,
+			List:     listName,
+			User:     email,
+			Message:  fmt.Sprintf("Invited %s to %s", email, listName),
+		})
+	}
+
+	users, err := client.GetAllUsersFromList(context.Background(), listUUID)
+	if err != nil {
+		return reportError(err)
+	}
+	output := usersOutput{ListUUID: listUUID, List: listName, Users: users.Users}
+	if output.Users == nil {
+		output.Users = []bring.GetAllUsersFromListEntry{}
+	}
+	return outputFor(flags).print(output, func() {
+		fmt.Printf("Users in: %s\n\n", listName)
+		for _, user := range users.Users {
+			fmt.Printf("  - %s (%s)\n", user.Name, user.Email)
+		}
+	})
+}
+
+func invitesCommand(positional []string, flags FlagSet) int {
+	if len(positional) > 0 && positional[0] != "accept" && positional[0] != "decline" {
+		fmt.Fprintf(os.Stderr, "Unknown invites command: %s\n", positional[0])
+		fmt.Fprintln(os.Stderr, "Usage: brings invites [accept|decline <invitation>]")
+		return 1
+	}
+	if len(positional) == 1 {
+		fmt.Fprintf(os.Stderr, "Usage: brings invites %s <invitation uuid or list name>\n", positional[0])
+		return 1
+	}
+	client, _, ok := getBringClient()
+	if !ok {
+		return 1
+	}
+	invites, err := client.GetPendingInvitations(context.Background())
+	if err != nil {
+		return reportError(err)
+	}
+
+	out := outputFor(flags)
+	if len(positional) == 0 {
+		output := invitesOutput{Invitations: []invitationOutput{}}
+		for _, invite := range invites.Invitations {
+			output.Invitations = append(output.Invitations, invitationOutput{
+				InvitationUUID: invite.UUID,
+				ListUUID:       invite.ListUUID,
+				List:           invite.ListName,
+				Inviter:        invite.InviterName,
+				InviterEmail:   invite.InviterEmail,
+				Status:         invite.Status,
+				Time:           invite.Time,
+			})
+		}
+		return out.print(output, func() {
+			if len(invites.Invitations) == 0 {
+				fmt.Println("No pending invitations")
+				return
+			}
+			fmt.Println("Pending invitations:")
+			fmt.Println()
+			for _, invite := range invites.Invitations {
+				from := invite.InviterName
+				if invite.InviterEmail != "" {
+					from = strings.TrimSpace(fmt.Sprintf("%s <%s>", invite.InviterName, invite.InviterEmail))
+				}
+				fmt.Printf("  %s  \"%s\" from %s\n", invite.UUID, invite.ListName, coalesce(from, "unknown"))
+			}
+			fmt.Println()
+			fmt.Println("Run `brings invites accept <uuid>` or `brings invites decline <uuid>`.")
+		})
+	}
+
+	invite, found := findInvitation(invites.Invitations, positional[1])
+	if !found {
+		return reportError(notFoundError{fmt.Sprintf("no pending invitation %q", positional[1])})
+	}
+	answered := result{Invitation: invite.UUID, ListUUID: invite.ListUUID, List: invite.ListName}
+	if positional[0] == "accept" {
+		if _, err := client.AcceptInvitation(context.Background(), invite.UUID); err != nil {
+			return reportError(err)
+		}
+		answered.Action = "invitation.accept"
+		answered.Message = fmt.Sprintf("Joined \"%s\"", invite.ListName)
+		return out.result(answered)
+	}
+	if _, err := client.DeclineInvitation(context.Background(), invite.UUID); err != nil {
+		return reportError(err)
+	}
+	answered.Action = "invitation.decline"
+	answered.Message = fmt.Sprintf("Declined invitation to \"%s\"", invite.ListName)
+	return out.result(answered)
+}
+
+// findInvitation matches an invitation by UUID or list name (case-insensitive).
+func findInvitation(invites []bring.Invitation, arg string) (bring.Invitation, bool) {
+	for _, invite := range invites {
+		if invite.UUID == arg {
+			return invite, true
+		}
+	}
+	for _, invite := range invites {
+		if invite.ListName != "" && strings.EqualFold(invite.ListName, arg) {
+			return invite, true
+		}
+	}
+	return bring.Invitation{}, false
+}
+
+func accountCommand(positional []string, flags FlagSet) int {
+	client, _, ok := getBringClient()
+	if !ok {
+		return 1
+	}
+	account, err := client.GetUserAccount(context.Background())
+	if err != nil {
+		return reportError(err)
+	}
+	output := accountOutput{
+		Name:          account.Name,
+		Email:         account.Email,
+		EmailVerified: account.EmailVerified,
+		Locale:        account.UserLocale.String(),
+		UserUUID:      account.UserUUID,
+		PublicUUID:    account.PublicUserUUID,
+		PhotoPath:     account.PhotoPath,
+		Premium:       account.PremiumConfiguration,
+	}
+	return outputFor(flags).print(output, func() {
+		fmt.Println("Account Information:")
+		fmt.Println()
+		fmt.Printf("  Name: %s\n", coalesce(account.Name, "N/A"))
+		fmt.Printf("  Email: %s\n", account.Email)
+		if account.EmailVerified {
+			fmt.Println("  Email Verified: Yes")
+		} else {
+			fmt.Println("  Email Verified: No")
+		}
+		fmt.Printf("  Locale: %s\n", coalesce(output.Locale, "N/A"))
+		fmt.Printf("  User UUID: %s\n", account.UserUUID)
+		fmt.Printf("  Public UUID: %s\n", account.PublicUserUUID)
+	})
+}
+
+func settingsCommand(positional []string, flags FlagSet) int {
+	client, _, ok := getBringClient()
+	if !ok {
+		return 1
+	}
+	settings, err := client.GetUserSettings(context.Background())
+	if err != nil {
+		return reportError(err)
+	}
+	output := settingsOutput{Settings: settingsMap(settings.UserSettings), Lists: []listSettingsOutput{}}
+	for _, listSetting := range settings.UserListSettings {
+		output.Lists = append(output.Lists, listSettingsOutput{ListUUID: listSetting.ListUUID, Settings: settingsMap(listSetting.UserSettings)})
 	}
-
-	if len(settings.UserListSettings) > 0 {
+	return outputFor(flags).print(output, func() {
+		fmt.Println("User Settings:")
 		fmt.Println()
-		fmt.Println("List Settings:")
-		for _, listSetting := range settings.UserListSettings {
-			fmt.Printf("\n  List: %s\n", listSetting.ListUUID)
-			for _, s := range listSetting.UserSettings {
-				fmt.Printf("    %s: %s\n", s.Key, s.Value)
+		for _, setting := range settings.UserSettings {
+			fmt.Printf("  %s: %s\n", setting.Key, setting.Value)
+		}
+
+		if len(settings.UserListSettings) > 0 {
+			fmt.Println()
+			fmt.Println("List Settings:")
+			for _, listSetting := range settings.UserListSettings {
+				fmt.Printf("\n  List: %s\n", listSetting.ListUUID)
+				for _, s := range listSetting.UserSettings {
+					fmt.Printf("    %s: %s\n", s.Key, s.Value)
+				}
 			}
 		}
+	})
+}
+
+// settingsMap turns settings entries into a map from key to value.
+func settingsMap(entries []bring.UserSettingsEntry) map[string]string {
+	settings := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		settings[entry.Key] = entry.Value
 	}
-	return 0
+	return settings
 }
 
-func configCommand(positional []string) int {
+func configCommand(positional []string, flags FlagSet) int {
+	out := outputFor(flags)
 	cfg := loadConfig()
+	values := configOutput{Servings: cfg.Servings, DefaultList: cfg.DefaultList, Locale: cfg.Locale, Path: getConfigPath()}
 	if len(positional) == 0 {
-		fmt.Println("Configuration:")
-		fmt.Println()
-		if cfg.Servings == 0 {
-			fmt.Println("  servings: (not set)")
-		} else {
-			fmt.Printf("  servings: %d\n", cfg.Servings)
-		}
-		if cfg.DefaultList == "" {
-			fmt.Println("  defaultList: (not set)")
-		} else {
-			fmt.Printf("  defaultList: %s\n", cfg.DefaultList)
-		}
-		if cfg.Locale == "" {
-			fmt.Println("  locale: (not set)")
-		} else {
-			fmt.Printf("  locale: %s\n", cfg.Locale)
-		}
-		fmt.Printf("\nConfig file: %s\n", getConfigPath())
-		return 0
-	}
-
-	key := positional[0]
-	if len(positional) == 1 {
-		switch key {
-		case "servings":
-			if cfg.Servings == 0 {
-				fmt.Println("servings: (not set)")
-			} else {
-				fmt.Printf("servings: %d\n", cfg.Servings)
+		return out.print(values, func() {
+			fmt.Println("Configuration:")
+			fmt.Println()
+			for _, key := range configKeys {
+				fmt.Printf("  %s: %s\n", key, coalesce(values.get(key), "(not set)"))
 			}
-		case "defaultList":
-			fmt.Printf("defaultList: %s\n", coalesce(cfg.DefaultList, "(not set)"))
-		case "locale":
-			fmt.Printf("locale: %s\n", coalesce(cfg.Locale, "(not set)"))
-		default:
-			fmt.Fprintf(os.Stderr, "Unknown config key: %s\n", key)
-			fmt.Fprintln(os.Stderr, "Valid keys: servings, defaultList, locale")
-			return 1
-		}
-		return 0
+			fmt.Printf("\nConfig file: %s\n", values.Path)
+		})
 	}
 
-	value := positional[1]
-	if value == "" {
+	key := positional[0]
+	if !slices.Contains(configKeys, key) {
 		fmt.Fprintf(os.Stderr, "Unknown config key: %s\n", key)
+		fmt.Fprintf(os.Stderr, "Valid keys: %s\n", strings.Join(configKeys, ", "))
 		return 1
 	}
+	if len(positional) == 1 {
+		entry := configEntryOutput{Key: key, Value: values.get(key)}
+		return out.print(entry, func() {
+			fmt.Printf("%s: %s\n", key, coalesce(entry.Value, "(not set)"))
+		})
+	}
 
+	value := positional[1]
 	switch key {
 	case "servings":
 		num, err := strconv.Atoi(value)
@@ -694,20 +1967,21 @@ func configCommand(positional []string) int {
 		cfg.DefaultList = value
 	case "locale":
 		cfg.Locale = value
-	default:
-		fmt.Fprintf(os.Stderr, "Unknown config key: %s\n", key)
-		return 1
 	}
 
 	if err := saveConfig(cfg); err != nil {
 		fmt.Fprintf(os.Stderr, "Error saving config: %s\n", err)
 		return 1
 	}
-	fmt.Printf("Set %s = %s\n", key, value)
-	return 0
+	return out.result(result{
+		Action:  "config.set",
+		Key:     key,
+		Value:   value,
+		Message: fmt.Sprintf("Set %s = %s", key, value),
+	})
 }
 
-func catalogCommand(positional []string) int {
+func catalogCommand(positional []string, flags FlagSet) int {
 	client, _, ok := getBringClient()
 	if !ok {
 		return 1
@@ -719,29 +1993,37 @@ func catalogCommand(positional []string) int {
 
 	catalog, err := client.LoadCatalog(context.Background(), locale)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-		return 1
+		return reportError(err)
 	}
 
-	fmt.Printf("Catalog (%s):\n", catalog.Language)
+	output := catalogOutput{Locale: coalesce(catalog.Language, locale), Sections: []catalogSectionOutput{}}
 	for _, section := range catalog.Catalog.Sections {
-		fmt.Printf("\n%s:\n", section.Name)
-		items := []string{}
-		for i, item := range section.Items {
-			if i >= 10 {
-				break
+		items := []catalogItemOutput{}
+		for _, item := range section.Items {
+			items = append(items, catalogItemOutput{ItemID: item.ItemID, Name: item.Name})
+		}
+		output.Sections = append(output.Sections, catalogSectionOutput{SectionID: section.SectionID, Name: section.Name, Items: items})
+	}
+	return outputFor(flags).print(output, func() {
+		fmt.Printf("Catalog (%s):\n", catalog.Language)
+		for _, section := range catalog.Catalog.Sections {
+			fmt.Printf("\n%s:\n", section.Name)
+			items := []string{}
+			for i, item := range section.Items {
+				if i >= 10 {
+					break
+				}
+				items = append(items, item.Name)
 			}
-			items = append(items, item.Name)
-		}
-		if len(items) > 0 {
-			fmt.Printf("  %s", strings.Join(items, ", "))
-			if len(section.Items) > 10 {
-				fmt.Print("...")
+			if len(items) > 0 {
+				fmt.Printf("  %s", strings.Join(items, ", "))
+				if len(section.Items) > 10 {
+					fmt.Print("...")
+				}
+				fmt.Println()
 			}
-			fmt.Println()
 		}
-	}
-	return 0
+	})
 }
 
 func addRecipeCommand(positional []string, flags FlagSet) int {
@@ -750,7 +2032,7 @@ func addRecipeCommand(positional []string, flags FlagSet) int {
 		return 1
 	}
 	if len(positional) == 0 {
-		fmt.Fprintln(os.Stderr, "Usage: brings add-recipe <recipe-uuid> [--list <uuid>] [--servings <n>]")
+		fmt.Fprintln(os.Stderr, "Usage: brings add-recipe <recipe-uuid> [--list <list>] [--servings <n>]")
 		fmt.Fprintln(os.Stderr, "\nGet the recipe UUID from `brings inspirations`")
 		return 1
 	}
@@ -758,36 +2040,19 @@ func addRecipeCommand(positional []string, flags FlagSet) int {
 
 	recipe, err := client.GetInspirationDetails(context.Background(), contentUUID)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-		return 1
+		return reportError(err)
 	}
-	title := coalesce(toString(recipe["title"]), toString(recipe["name"]), "Recipe")
+	title := coalesce(recipe.Title, "Recipe")
 
 	listUUID, listName, err := getListUUID(client, flags.Get("list"))
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-		return 1
-	}
-
-	recipeServings := parseServings(recipe["yield"], recipe["baseQuantity"], recipe["servings"])
-	targetServings := 0
-	if flags.Get("servings") != "" {
-		if v, err := strconv.Atoi(flags.Get("servings")); err == nil {
-			targetServings = v
-		}
-	} else if cfg.Servings > 0 {
-		targetServings = cfg.Servings
+		return reportError(err)
 	}
 
-	scale := 1.0
-	if recipeServings > 0 && targetServings > 0 {
-		scale = float64(targetServings) / float64(recipeServings)
-	}
+	recipeServings := recipe.Servings
+	targetServings, scale := recipeScale(recipe, cfg, flags)
 
-	items := toSlice(recipe["items"])
-	if len(items) == 0 {
-		items = toSlice(recipe["ingredients"])
-	}
+	items := recipe.Ingredients
 	if len(items) == 0 {
 		fmt.Fprintln(os.Stderr, "Recipe has no ingredients")
 		return 1
@@ -795,49 +2060,54 @@ func addRecipeCommand(positional []string, flags FlagSet) int {
 
 	batchItems := []bring.BatchUpdateItem{}
 	for _, item := range items {
-		m := toMap(item)
-		if !flags.Has("all") && toBool(m["stock"]) {
+		if !flags.Has("all") && item.Stock {
 			continue
 		}
-		name := coalesce(toString(m["itemId"]), toString(m["name"]), "")
-		if name == "" {
-			continue
-		}
-		spec := toString(m["spec"])
-		spec = scaleSpec(spec, scale)
-		batchItems = append(batchItems, bring.BatchUpdateItem{ItemID: name, Spec: spec})
+		batchItems = append(batchItems, bring.BatchUpdateItem{ItemID: item.ItemName(), Spec: scaleSpec(item.Spec, scale)})
 	}
 
+	added := result{
+		Action:     "add-recipe",
+		ListUUID:   listUUID,
+		List:       listName,
+		Recipe:     title,
+		RecipeUUID: contentUUID,
+		Items:      []recipeIngredientOutput{},
+		Skipped:    len(items) - len(batchItems),
+	}
 	if len(batchItems) == 0 {
-		fmt.Println("All ingredients are pantry items. Use --all to add them anyway.")
-		return 0
+		added.Message = "All ingredients are pantry items. Use --all to add them anyway."
+		return outputFor(flags).result(added)
 	}
 
 	if _, err := client.BatchUpdateItems(context.Background(), listUUID, batchItems, bring.BringItemToPurchase); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-		return 1
+		return reportError(err)
 	}
 
-	fmt.Printf("\nAdded %d ingredients from \"%s\" to %s\n", len(batchItems), title, listName)
+	var message strings.Builder
+	fmt.Fprintf(&message, "Added %d ingredients from \"%s\" to %s\n", len(batchItems), title, listName)
 	if scale != 1 && recipeServings > 0 && targetServings > 0 {
-		fmt.Printf("(Scaled from %d to %d servings)\n", recipeServings, targetServings)
+		fmt.Fprintf(&message, "(Scaled from %d to %d servings)\n", recipeServings, targetServings)
 	}
 
-	fmt.Println("\nItems added:")
+	message.WriteString("\nItems added:")
 	for _, item := range batchItems {
+		added.Items = append(added.Items, recipeIngredientOutput{Name: item.ItemID, Spec: item.Spec})
 		if item.Spec != "" {
-			fmt.Printf("  - %s (%s)\n", item.ItemID, item.Spec)
+			fmt.Fprintf(&message, "\n  - %s (%s)", item.ItemID, item.Spec)
 		} else {
-			fmt.Printf("  - %s\n", item.ItemID)
+			fmt.Fprintf(&message, "\n  - %s", item.ItemID)
 		}
 	}
 
-	if !flags.Has("all") && len(batchItems) < len(items) {
-		skipped := len(items) - len(batchItems)
-		fmt.Printf("\n%d pantry item(s) skipped. Use --all to include them.\n", skipped)
+	if added.Skipped > 0 {
+		fmt.Fprintf(&message, "\n\n%d pantry item(s) skipped. Use --all to include them.", added.Skipped)
 	}
-
-	return 0
+	added.Message = message.String()
+	return outputFor(flags).print(added, func() {
+		fmt.Println()
+		fmt.Println(added.Message)
+	})
 }
 
 func recipeCommand(positional []string, flags FlagSet) int {
@@ -854,91 +2124,77 @@ func recipeCommand(positional []string, flags FlagSet) int {
 
 	recipe, err := client.GetInspirationDetails(context.Background(), contentUUID)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-		return 1
+		return reportError(err)
 	}
 	format, pretty, err := parseOutputFormat(flags, "json")
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-		return 1
+		return reportError(err)
 	}
 	if flags.Has("debug") {
-		printJSON(recipe, true)
+		printJSON(recipe.Raw, true)
 		return 0
 	}
 
-	title := coalesce(toString(recipe["title"]), toString(recipe["name"]), "Recipe")
-	author := coalesce(toString(recipe["author"]), toString(recipe["attribution"]))
-	likes := toInt(recipe["likeCount"])
-
-	recipeServings := parseServings(recipe["yield"], recipe["baseQuantity"], recipe["servings"])
-	targetServings := 0
-	if flags.Get("servings") != "" {
-		if v, err := strconv.Atoi(flags.Get("servings")); err == nil {
-			targetServings = v
-		}
-	} else if cfg.Servings > 0 {
-		targetServings = cfg.Servings
-	}
-
-	scale := 1.0
-	if recipeServings > 0 && targetServings > 0 {
-		scale = float64(targetServings) / float64(recipeServings)
+	targetServings, scale := recipeScale(recipe, cfg, flags)
+	output := recipeOutput{
+		SchemaVersion:  recipeSchemaVersion,
+		ID:             contentUUID,
+		Title:          coalesce(recipe.Title, "Recipe"),
+		Author:         recipe.Author,
+		Likes:          recipe.LikeCount,
+		ImageURL:       recipe.ImageURL,
+		SourceURL:      recipe.LinkOutURL,
+		Servings:       recipe.Servings,
+		TargetServings: targetServings,
+		Scale:          scale,
+		Ingredients:    recipeIngredients(recipe, scale),
+		Instructions:   recipeInstructions(recipe),
+		Nutrition:      recipe.Nutrition,
 	}
 
-	ingredients := recipeIngredients(recipe, scale)
-	nutrition := recipeNutrition(recipe)
-	instructions := recipeInstructions(recipe)
-
 	if format != "human" {
-		output := recipeOutput{
-			ID:        contentUUID,
-			Title:     title,
-			ImageURL:  imageURLFromContent(recipe),
-			Nutrition: nutrition,
-		}
 		printJSON(output, pretty)
 		return 0
 	}
 
-	fmt.Printf("\n%s\n", title)
-	fmt.Println(strings.Repeat("=", len(title)))
+	fmt.Printf("\n%s\n", output.Title)
+	fmt.Println(strings.Repeat("=", len(output.Title)))
 
-	if author != "" {
-		fmt.Printf("Source: %s\n", author)
+	if output.Author != "" {
+		fmt.Printf("Source: %s\n", output.Author)
 	}
-	if likes > 0 {
-		fmt.Printf("Likes: %d\n", likes)
+	if output.Likes > 0 {
+		fmt.Printf("Likes: %d\n", output.Likes)
 	}
-	if flags.Has("images") || flags.Has("image") {
-		if image := imageURLFromContent(recipe); image != "" {
-			fmt.Printf("Image: %s\n", image)
+	if flags.Has("images") {
+		if output.ImageURL != "" {
+			fmt.Printf("Image: %s\n", output.ImageURL)
 		}
 	}
 
-	if recipeServings > 0 {
+	if output.Servings > 0 {
 		if scale != 1 && targetServings > 0 {
-			fmt.Printf("Servings: %d -> scaled to %d\n", recipeServings, targetServings)
+			fmt.Printf("Servings: %d -> scaled to %d\n", output.Servings, targetServings)
 		} else {
-			fmt.Printf("Servings: %d\n", recipeServings)
+			fmt.Printf("Servings: %d\n", output.Servings)
 		}
 	}
 
-	if len(nutrition) > 0 {
+	if len(output.Nutrition) > 0 {
 		nutritionKeys := []string{}
-		for key := range nutrition {
+		for key := range output.Nutrition {
 			nutritionKeys = append(nutritionKeys, key)
 		}
 		sort.Strings(nutritionKeys)
 		fmt.Println("\nNutrition:")
 		for _, key := range nutritionKeys {
-			fmt.Printf("  %s: %s\n", key, nutrition[key])
+			fmt.Printf("  %s: %s\n", key, output.Nutrition[key])
 		}
 	}
 
-	if len(ingredients) > 0 {
+	if len(output.Ingredients) > 0 {
 		fmt.Println("\nIngredients:")
-		for _, item := range ingredients {
+		for _, item := range output.Ingredients {
 			stockNote := ""
 			if item.Pantry {
 				stockNote = " (pantry)"
@@ -951,21 +2207,40 @@ func recipeCommand(positional []string, flags FlagSet) int {
 		}
 	}
 
-	if len(instructions) > 0 {
+	if len(output.Instructions) > 0 {
 		fmt.Println()
 		fmt.Println("Instructions:")
-		for i, step := range instructions {
+		for i, step := range output.Instructions {
 			fmt.Printf("  %d. %s\n", i+1, step)
 		}
 	}
 
-	if link := toString(recipe["linkOutUrl"]); link != "" {
-		fmt.Printf("\nSource: %s\n", link)
+	if output.SourceURL != "" {
+		fmt.Printf("\nSource: %s\n", output.SourceURL)
 	}
 
 	return 0
 }
 
+// recipeScale returns the servings to scale a recipe to, from --servings or
+// the configured servings, and the factor to scale its amounts by. The
+// target is 0 when neither is set.
+func recipeScale(recipe bring.Recipe, cfg Config, flags FlagSet) (int, float64) {
+	targetServings := 0
+	if flags.Get("servings") != "" {
+		if v, err := strconv.Atoi(flags.Get("servings")); err == nil {
+			targetServings = v
+		}
+	} else if cfg.Servings > 0 {
+		targetServings = cfg.Servings
+	}
+
+	if recipe.Servings > 0 && targetServings > 0 {
+		return targetServings, float64(targetServings) / float64(recipe.Servings)
+	}
+	return targetServings, 1
+}
+
 func inspirationsCommand(positional []string, flags FlagSet) int {
 	client, _, ok := getBringClient()
 	if !ok {
@@ -974,8 +2249,7 @@ func inspirationsCommand(positional []string, flags FlagSet) int {
 
 	format, pretty, err := parseOutputFormat(flags, "json")
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-		return 1
+		return reportError(err)
 	}
 
 	filter := "mine"
@@ -986,8 +2260,7 @@ func inspirationsCommand(positional []string, flags FlagSet) int {
 	if flags.Has("filters") {
 		filters, err := client.GetInspirationFilters(context.Background())
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-			return 1
+			return reportError(err)
 		}
 		if format == "human" {
 			fmt.Println("Available Filters:")
@@ -1031,8 +2304,7 @@ func inspirationsCommand(positional []string, flags FlagSet) int {
 
 	inspirations, err := client.GetInspirations(context.Background(), filter)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-		return 1
+		return reportError(err)
 	}
 
 	if flags.Has("debug") {
@@ -1047,16 +2319,11 @@ func inspirationsCommand(positional []string, flags FlagSet) int {
 		}
 		entries := make([]inspirationOutput, 0, limit)
 		for _, entry := range inspirations.Entries[:limit] {
-			content := toMap(entry["content"])
-			if len(content) == 0 {
-				content = entry
-			}
-			item := inspirationOutput{
-				ID:       coalesce(toString(content["contentUuid"]), toString(content["uuid"]), toString(entry["uuid"])),
-				Title:    coalesce(toString(content["title"]), toString(content["name"]), toString(content["campaign"])),
-				ImageURL: imageURLFromContent(content),
-			}
-			entries = append(entries, item)
+			entries = append(entries, inspirationOutput{
+				ID:       entry.ID,
+				Title:    entry.Title,
+				ImageURL: entry.ImageURL,
+			})
 		}
 		printJSON(inspirationsOutput{
 			Filter:  filter,
@@ -1079,17 +2346,13 @@ func inspirationsCommand(positional []string, flags FlagSet) int {
 	}
 
 	for _, entry := range inspirations.Entries[:limit] {
-		content := toMap(entry["content"])
-		if len(content) == 0 {
-			content = entry
-		}
-		title := coalesce(toString(content["title"]), toString(content["name"]), toString(content["campaign"]), "Untitled")
-		author := coalesce(toString(content["author"]), toString(content["attribution"]))
+		title := coalesce(entry.Title, "Untitled")
+		author := entry.Author
 		likes := ""
-		if count := toInt(content["likeCount"]); count > 0 {
-			likes = fmt.Sprintf("%d likes", count)
+		if entry.LikeCount > 0 {
+			likes = fmt.Sprintf("%d likes", entry.LikeCount)
 		}
-		uuid := toString(content["contentUuid"])
+		uuid := entry.ID
 
 		fmt.Printf("\n  %s\n", title)
 		meta := []string{}
@@ -1099,8 +2362,8 @@ func inspirationsCommand(positional []string, flags FlagSet) int {
 		if likes != "" {
 			meta = append(meta, likes)
 		}
-		if ctype := toString(content["type"]); ctype != "" {
-			meta = append(meta, ctype)
+		if entry.Type != "" {
+			meta = append(meta, entry.Type)
 		}
 		if len(meta) > 0 {
 			fmt.Printf("    %s\n", strings.Join(meta, " | "))
@@ -1108,18 +2371,14 @@ func inspirationsCommand(positional []string, flags FlagSet) int {
 		if uuid != "" {
 			fmt.Printf("    ID: %s\n", uuid)
 		}
-		if flags.Has("images") || flags.Has("image") {
-			if image := imageURLFromContent(content); image != "" {
-				fmt.Printf("    Image: %s\n", image)
+		if flags.Has("images") {
+			if entry.ImageURL != "" {
+				fmt.Printf("    Image: %s\n", entry.ImageURL)
 			}
 		}
-		if tags := toSlice(content["tags"]); len(tags) > 0 {
+		if len(entry.Tags) > 0 {
 			relevant := []string{}
-			for _, tag := range tags {
-				value := toString(tag)
-				if value == "" {
-					continue
-				}
+			for _, value := range entry.Tags {
 				if value == "all" || value == "mine" || value == "type_recipe" || value == "bring_recipe_parser" {
 					continue
 				}
@@ -1130,8 +2389,8 @@ func inspirationsCommand(positional []string, flags FlagSet) int {
 			}
 		}
 		if flags.Has("verbose") {
-			if link := toString(content["linkOutUrl"]); link != "" {
-				fmt.Printf("    URL: %s\n", link)
+			if entry.LinkOutURL != "" {
+				fmt.Printf("    URL: %s\n", entry.LinkOutURL)
 			}
 		}
 	}
@@ -1146,7 +2405,7 @@ func notifyCommand(positional []string, flags FlagSet) int {
 		return 1
 	}
 	if len(positional) == 0 {
-		fmt.Fprintln(os.Stderr, "Usage: brings notify <type> [--message \"msg\"] [--list <uuid>]")
+		fmt.Fprintln(os.Stderr, "Usage: brings notify <type> [--message \"msg\"] [--list <list>]")
 		fmt.Fprintln(os.Stderr, "\nTypes: GOING_SHOPPING, CHANGED_LIST, SHOPPING_DONE, URGENT_MESSAGE")
 		return 1
 	}
@@ -1159,24 +2418,33 @@ func notifyCommand(positional []string, flags FlagSet) int {
 		"URGENT_MESSAGE": true,
 	}
 	if !valid[notifyType] {
-		fmt.Fprintln(os.Stderr, "Usage: brings notify <type> [--message \"msg\"] [--list <uuid>]")
+		fmt.Fprintln(os.Stderr, "Usage: brings notify <type> [--message \"msg\"] [--list <list>]")
 		fmt.Fprintln(os.Stderr, "\nTypes: GOING_SHOPPING, CHANGED_LIST, SHOPPING_DONE, URGENT_MESSAGE")
 		return 1
 	}
 
+	message := flags.Get("message")
+	if notifyType == string(bring.NotifyUrgentMessage) && message == "" {
+		fmt.Fprintln(os.Stderr, "Error: URGENT_MESSAGE requires --message \"msg\"")
+		return 1
+	}
+
 	listUUID, listName, err := getListUUID(client, flags.Get("list"))
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-		return 1
+		return reportError(err)
 	}
 
-	message := flags.Get("message")
 	if _, err := client.Notify(context.Background(), listUUID, bring.BringNotificationType(notifyType), message, nil, "", "", ""); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-		return 1
-	}
-	fmt.Printf("Notification \"%s\" sent to %s\n", notifyType, listName)
-	return 0
+		return reportError(err)
+	}
+	return outputFor(flags).result(result{
+		Action:       "notify",
+		ListUUID:     listUUID,
+		List:         listName,
+		Notification: notifyType,
+		Text:         message,
+		Message:      fmt.Sprintf("Notification \"%s\" sent to %s", notifyType, listName),
+	})
 }
 
 func getBringClient() (*bring.Bring, Config, bool) {
@@ -1185,24 +2453,163 @@ func getBringClient() (*bring.Bring, Config, bool) {
 		fmt.Fprintln(os.Stderr, "Not logged in. Run `brings login` first.")
 		return nil, cfg, false
 	}
+	opts, err := clientOptions()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		return nil, cfg, false
+	}
 
 	client := bring.FromToken(bring.TokenAuthOptions{
 		AccessToken:    cfg.AccessToken,
+		RefreshToken:   cfg.RefreshToken,
 		UserUUID:       cfg.UserUUID,
 		PublicUserUUID: cfg.PublicUserUUID,
 		URL:            getBaseURL(),
-	})
+		OnTokenRefresh: func(tokens bring.AuthTokens) {
+			saved := loadConfig()
+			saved.AccessToken = tokens.AccessToken
+			saved.RefreshToken = tokens.RefreshToken
+			if err := saveConfig(saved); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: could not save refreshed token: %s\n", err)
+			}
+		},
+	}, opts...)
 	return client, cfg, true
 }
 
-func getListUUID(client *bring.Bring, listArg string) (string, string, error) {
-	if listArg != "" {
-		return listArg, listArg, nil
+// clientOptions returns client options configured through the environment.
+func clientOptions() ([]bring.Option, error) {
+	opts := []bring.Option{
+		bring.WithUserAgent("brings-cli"),
+		bring.WithCacheDir(getCacheDir()),
+	}
+	if country := os.Getenv("BRINGS_COUNTRY"); country != "" {
+		opts = append(opts, bring.WithCountry(strings.ToUpper(country)))
+	}
+	if os.Getenv("BRINGS_DEBUG") != "" {
+		logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		opts = append(opts, bring.WithLogger(logger))
+	}
+	if path := os.Getenv("BRINGS_REPLAY"); path != "" {
+		replayer, err := bring.NewReplayer(path)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, bring.WithTransport(replayer))
+	} else if path := os.Getenv("BRINGS_RECORD"); path != "" {
+		opts = append(opts, bring.WithTransport(bring.NewRecorder(path, nil)))
+	}
+	return opts, nil
+}
+
+// reportError prints err with a hint for known API failures and returns the
+// matching exit code.
+func reportError(err error) int {
+	fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+	switch {
+	case errors.Is(err, bring.ErrUnauthorized):
+		fmt.Fprintln(os.Stderr, "Hint: your session is no longer valid. Run `brings login` to authenticate again.")
+		return exitUnauthorized
+	case errors.Is(err, bring.ErrNotFound):
+		fmt.Fprintln(os.Stderr, "Hint: check the list or item ID. Run `brings lists` to see available lists.")
+		return exitNotFound
+	case errors.Is(err, bring.ErrRateLimited):
+		var apiErr *bring.APIError
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+			fmt.Fprintf(os.Stderr, "Hint: too many requests. Try again in %s.\n", apiErr.RetryAfter.Round(time.Second))
+		} else {
+			fmt.Fprintln(os.Stderr, "Hint: too many requests. Wait a moment and try again.")
+		}
+		return exitRateLimited
+	}
+	return exitError
+}
+
+// findList returns the list whose UUID or name (case-insensitive) is arg.
+func findList(client *bring.Bring, arg string) (bring.LoadListsEntry, error) {
+	lists, err := client.LoadLists(context.Background())
+	if err != nil {
+		return bring.LoadListsEntry{}, err
+	}
+	return matchList(lists.Lists, arg)
+}
+
+// matchList picks the list whose UUID is arg, then the list whose name
+// equals arg, first exactly and then ignoring case, then the list whose name
+// starts with arg, ignoring case. Several matches at the same step are an
+// error naming the candidates.
+func matchList(lists []bring.LoadListsEntry, arg string) (bring.LoadListsEntry, error) {
+	for _, list := range lists {
+		if list.ListUUID == arg {
+			return list, nil
+		}
+	}
+	var matches []bring.LoadListsEntry
+	for _, match := range []func(name string) bool{
+		func(name string) bool { return name == arg },
+		func(name string) bool { return strings.EqualFold(name, arg) },
+		func(name string) bool { return strings.HasPrefix(strings.ToLower(name), strings.ToLower(arg)) },
+	} {
+		for _, list := range lists {
+			if match(list.Name) {
+				matches = append(matches, list)
+			}
+		}
+		if len(matches) > 0 {
+			break
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return bring.LoadListsEntry{}, notFoundError{fmt.Sprintf("no list named %q", arg)}
+	case 1:
+		return matches[0], nil
 	}
+	candidates := make([]string, 0, len(matches))
+	for _, list := range matches {
+		candidates = append(candidates, fmt.Sprintf("%s (%s)", list.Name, list.ListUUID))
+	}
+	return bring.LoadListsEntry{}, fmt.Errorf("%q matches several lists: %s", arg, strings.Join(candidates, ", "))
+}
+
+// notFoundError reports a list or item missing locally; it matches
+// bring.ErrNotFound so it maps to the same exit code as a 404.
+type notFoundError struct {
+	msg string
+}
+
+func (e notFoundError) Error() string {
+	return e.msg
+}
+
+func (e notFoundError) Is(target error) bool {
+	return target == bring.ErrNotFound
+}
+
+// getListUUID resolves the list to work on from --list, BRINGS_LIST or the
+// configured defaultList, in that order, falling back to the first list.
+// It returns the list UUID and name.
+func getListUUID(client *bring.Bring, listArg string) (string, string, error) {
 	lists, err := client.LoadLists(context.Background())
 	if err != nil {
 		return "", "", err
 	}
+	for _, source := range []struct {
+		name, value string
+	}{
+		{"--list", listArg},
+		{"BRINGS_LIST", os.Getenv("BRINGS_LIST")},
+		{"defaultList", loadConfig().DefaultList},
+	} {
+		if source.value == "" {
+			continue
+		}
+		list, err := matchList(lists.Lists, source.value)
+		if err != nil {
+			return "", "", fmt.Errorf("%s: %w", source.name, err)
+		}
+		return list.ListUUID, list.Name, nil
+	}
 	if len(lists.Lists) == 0 {
 		return "", "", fmt.Errorf("no shopping lists found")
 	}
@@ -1214,20 +2621,51 @@ func showHelp() {
 brings - CLI for Bring! Shopping Lists
 
 Usage: brings <command> [options]
+       brings help <command>     Show the arguments and flags of a command
 
 Authentication:
   login --browser           Open browser for login (recommended)
   login --token <token>     Login with token directly
+    --refresh-token <token>   Store a refresh token for automatic renewal
   logout                    Clear saved credentials
   status                    Show login status and token expiry
 
 Shopping List:
   lists                     Show all shopping lists
-  items [--list <uuid>]     Show items to purchase
+  lists create <name>       Create a list
+    --theme <theme>           home (default), bbq, office, holiday, party
+  lists rename <list> <name>  Rename a list (by name or UUID)
+  lists theme <list> <theme>  Change the theme of a list
+  lists delete <list>       Delete a list after confirmation
+    --yes                     Skip the confirmation prompt
+  items [--list <list>]     Show items to purchase, numbered
     --all                     Include recent/completed items
-  add <item> [--spec ".."]  Add item to list
-  remove <item>             Remove item from list
-  complete <item>           Mark item as purchased
+    --format <mode>           Output format: human (default) | json | pretty
+    --mine                    Only items assigned to you
+    --assignee <user>         Only items assigned to a member (name or email)
+  watch [--list <list>]     Print added, removed, completed and changed items as they happen
+    --interval <duration>     Time between polls (default: 10s)
+    --count <n>               Stop after n changes
+  add <item>... [--spec ".."]  Add items to list in one request
+    Milk "Bread:whole grain"  Give a spec inline; - reads items from stdin
+    --urgent, --convenient, --discounted  Set item attributes
+  recent                    Show recently purchased items, numbered
+  recent clear [--yes]      Clear the recently purchased items
+  readd <name|index>...     Put recent items back on the list with their old spec
+  edit <item> --spec <text> Change the specification of an item in place
+    --append                  Add the text to the existing specification
+    --name <name>             Replace the item with one of another name
+  flag <item>               Change item attributes
+    --urgent[=false]          Also --convenient, --discounted
+  assign <item> <user>      Assign an item to a list member (name or email)
+    --clear                   Remove the assignment
+  image set <item> <file>   Attach a JPEG or PNG photo to an item
+  image rm <item>           Remove the photo of an item
+  remove <item>...          Remove items from list
+  complete <item>...        Mark items as purchased
+    <item> is a name, the number shown by items, or a UUID; - reads stdin
+    --spec <text>             Pick between items with the same name
+    --all-matching            Change every item with the name
 
 Recipes (for AI agents):
   inspirations [filter]     List saved recipes with IDs and tags
@@ -1237,7 +2675,10 @@ Recipes (for AI agents):
     --images                  Include image URLs
   recipe <id>               Show recipe details and ingredients
     --format <mode>            Output format: json (default) | human | pretty
-    JSON fields (default):     {id, title, imageUrl, nutrition} (best for agents)
+    JSON fields (default):     {schemaVersion, id, title, author, servings, targetServings,
+                               scale, ingredients: [{name, spec, rawSpec, pantry}],
+                               instructions, sourceUrl, imageUrl, nutrition}
+    --servings <n>            Scale for n servings (default: config)
     --images                  Include image URLs
   add-recipe <id>           Add recipe ingredients to shopping list
     --servings <n>            Scale for n servings (default: config or recipe)
@@ -1245,6 +2686,10 @@ Recipes (for AI agents):
 
 Social:
   users                     Show users sharing the list
+  users invite <email>      Invite someone to the list
+  invites                   Show pending invitations
+  invites accept <id>       Accept an invitation (UUID or list name)
+  invites decline <id>      Decline an invitation
   notify <type>             Send notification (GOING_SHOPPING, SHOPPING_DONE, etc.)
   activity                  Show recent list activity
 
@@ -1252,15 +2697,34 @@ Settings:
   account                   Show account information
   config                    Show current configuration
   config servings <n>       Set default servings for recipes
-  config defaultList <uuid> Set default shopping list
+  config defaultList <list> Set default shopping list (name or UUID)
   catalog [locale]          Browse item catalog
 
+Output:
+  --format <mode>           Every command: human (default) | json | pretty
+                            Changes print a result: {action, listUuid, list, item, ..., message}
+                            watch prints one change per line: {type, listUuid, item, previousSpec, time}
+
 Agent Workflow:
   1. brings inspirations         -> List recipes with IDs
   2. brings add-recipe <id>      -> Add to shopping list (scaled to config servings)
 
   Optional: brings recipe <id>   -> Preview ingredients before adding
 
+Environment:
+  BRINGS_LIST               List to use when --list is not given (name or UUID)
+  BRINGS_COUNTRY            Country header sent to the API (default: DE)
+  BRINGS_DEBUG              Log HTTP requests to stderr when set
+  BRINGS_RECORD             Record HTTP exchanges to this cassette file (secrets redacted)
+  BRINGS_REPLAY             Answer requests from this cassette file instead of the API
+
+Exit Codes:
+  0  Success
+  1  General error
+  3  Not authorized (run ` + "`brings login`" + `)
+  4  List, item or recipe not found
+  5  Rate limited by the API
+
 Examples:
   brings inspirations              List saved recipes
   brings add-recipe abc-123        Add recipe to cart
@@ -1307,27 +2771,6 @@ func toString(value interface{}) string {
 	}
 }
 
-func toInt(value interface{}) int {
-	switch v := value.(type) {
-	case int:
-		return v
-	case int64:
-		return int(v)
-	case float64:
-		return int(v)
-	case json.Number:
-		if i, err := v.Int64(); err == nil {
-			return int(i)
-		}
-	}
-	if s := toString(value); s != "" {
-		if i, err := strconv.Atoi(s); err == nil {
-			return i
-		}
-	}
-	return 0
-}
-
 func toFloat(value interface{}) float64 {
 	switch v := value.(type) {
 	case float64:
@@ -1349,21 +2792,6 @@ func toFloat(value interface{}) float64 {
 	return 0
 }
 
-func toBool(value interface{}) bool {
-	switch v := value.(type) {
-	case bool:
-		return v
-	case string:
-		return v == "true" || v == "1"
-	case float64:
-		return v != 0
-	case int:
-		return v != 0
-	default:
-		return false
-	}
-}
-
 func toMap(value interface{}) map[string]interface{} {
 	if m, ok := value.(map[string]interface{}); ok {
 		return m
@@ -1371,13 +2799,6 @@ func toMap(value interface{}) map[string]interface{} {
 	return map[string]interface{}{}
 }
 
-func toSlice(value interface{}) []interface{} {
-	if s, ok := value.([]interface{}); ok {
-		return s
-	}
-	return []interface{}{}
-}
-
 func toStringSlice(values []interface{}) []string {
 	out := []string{}
 	for _, value := range values {
@@ -1391,7 +2812,7 @@ func toStringSlice(values []interface{}) []string {
 func parseOutputFormat(flags FlagSet, defaultFormat string) (string, bool, error) {
 	format := strings.ToLower(flags.Get("format"))
 	if format == "" {
-		if flags.Has("format") {
+		if _, set := flags.Values["format"]; set {
 			return "", false, errors.New("format requires a value: json | human | pretty")
 		}
 		format = defaultFormat
@@ -1426,138 +2847,25 @@ func printJSON(value interface{}, pretty bool) {
 	fmt.Println(string(data))
 }
 
-func imageURLFromContent(content map[string]interface{}) string {
-	if url := toString(content["imageUrl"]); url != "" {
-		return url
-	}
-	if url := toString(content["imageURL"]); url != "" {
-		return url
-	}
-	if url := toString(content["thumbnailUrl"]); url != "" {
-		return url
-	}
-	if url := toString(content["previewImageUrl"]); url != "" {
-		return url
-	}
-	if url := toString(content["imagePreviewUrl"]); url != "" {
-		return url
-	}
-	if url := toString(content["imagePreviewUrlSquare"]); url != "" {
-		return url
-	}
-	if image := content["image"]; image != nil {
-		if url := toString(image); url != "" {
-			return url
-		}
-		if m, ok := image.(map[string]interface{}); ok {
-			if url := toString(m["url"]); url != "" {
-				return url
-			}
-			if url := toString(m["imageUrl"]); url != "" {
-				return url
-			}
-		}
-	}
-	if images := toSlice(content["images"]); len(images) > 0 {
-		for _, image := range images {
-			if url := toString(image); url != "" {
-				return url
-			}
-			if m, ok := image.(map[string]interface{}); ok {
-				if url := toString(m["url"]); url != "" {
-					return url
-				}
-				if url := toString(m["imageUrl"]); url != "" {
-					return url
-				}
-			}
-		}
-	}
-	return ""
-}
-
-func recipeIngredients(recipe map[string]interface{}, scale float64) []recipeIngredientOutput {
-	items := toSlice(recipe["items"])
-	if len(items) == 0 {
-		items = toSlice(recipe["ingredients"])
-	}
-	if len(items) == 0 {
-		return nil
-	}
-	ingredients := make([]recipeIngredientOutput, 0, len(items))
-	for _, item := range items {
-		m := toMap(item)
-		name := coalesce(toString(m["itemId"]), toString(m["name"]), toString(m["text"]))
-		if name == "" {
-			continue
-		}
-		spec := scaleSpec(toString(m["spec"]), scale)
+func recipeIngredients(recipe bring.Recipe, scale float64) []recipeIngredientOutput {
+	ingredients := make([]recipeIngredientOutput, 0, len(recipe.Ingredients))
+	for _, item := range recipe.Ingredients {
 		ingredients = append(ingredients, recipeIngredientOutput{
-			Name:   name,
-			Spec:   spec,
-			Pantry: toBool(m["stock"]),
+			Name:    item.ItemName(),
+			Spec:    scaleSpec(item.Spec, scale),
+			RawSpec: item.Spec,
+			Pantry:  item.Stock,
 		})
 	}
 	return ingredients
 }
 
-func recipeInstructions(recipe map[string]interface{}) []string {
-	steps := recipe["instructions"]
-	if steps == nil {
-		steps = recipe["steps"]
-	}
-	switch v := steps.(type) {
-	case []interface{}:
-		lines := []string{}
-		for _, step := range v {
-			if text, ok := step.(string); ok {
-				if text != "" {
-					lines = append(lines, text)
-				}
-				continue
-			}
-			m := toMap(step)
-			text := coalesce(toString(m["text"]), toString(m["description"]))
-			if text != "" {
-				lines = append(lines, text)
-			}
-		}
-		return lines
-	case string:
-		if v != "" {
-			return []string{v}
-		}
-	}
-	return nil
-}
-
-func recipeNutrition(recipe map[string]interface{}) map[string]string {
-	raw, ok := recipe["nutrition"].(map[string]interface{})
-	if !ok {
-		return nil
-	}
-	out := map[string]string{}
-	for key, value := range raw {
-		if val := toString(value); val != "" {
-			out[key] = val
-		}
-	}
-	if len(out) == 0 {
-		return nil
-	}
-	return out
-}
-
-func parseServings(values ...interface{}) int {
-	for _, value := range values {
-		if value == nil {
-			continue
-		}
-		if i := toInt(value); i > 0 {
-			return i
-		}
+func recipeInstructions(recipe bring.Recipe) []string {
+	lines := make([]string, 0, len(recipe.Steps))
+	for _, step := range recipe.Steps {
+		lines = append(lines, step.Text)
 	}
-	return 0
+	return lines
 }
 
 var specAmountRe = regexp.MustCompile(`^([\d.,]+)\s*`)
@@ -1,16 +1,24 @@
 package cli
 
 import (
+	"bytes"
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
 	"io"
 	"net/http"
 	"net/http/httptest"
-	"net/url"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/benithors/brings-cli/bring"
+	"github.com/benithors/brings-cli/bring/bringtest"
 )
 
 func runCLI(args []string) (string, string, int) {
@@ -77,6 +85,8 @@ func TestItemsCommandOutput(t *testing.T) {
 			_ = json.NewEncoder(w).Encode(map[string]interface{}{
 				"lists": []map[string]string{{"listUuid": "list-1", "name": "Groceries"}},
 			})
+		case "/bringlists/list-1/details":
+			_, _ = w.Write([]byte("[]"))
 		case "/bringlists/list-1":
 			_ = json.NewEncoder(w).Encode(map[string]interface{}{
 				"purchase": []map[string]string{{"name": "Milk", "specification": "2%"}},
@@ -110,16 +120,45 @@ func TestItemsCommandOutput(t *testing.T) {
 	}
 }
 
+func TestItemsCommandWithOnlyRecentItems(t *testing.T) {
+	server := bringtest.NewServer()
+	defer server.Close()
+	listUUID := server.AddList("Groceries")
+	server.AddRecent(listUUID, "Bread", "")
+	account := server.Account()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("BRINGS_BASE_URL", server.URL)
+	if err := saveConfig(Config{AccessToken: account.AccessToken, UserUUID: account.UUID}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	stdout, stderr, code := runCLI([]string{"items"})
+	if code != 0 || !strings.Contains(stdout, "Shopping list is empty") {
+		t.Fatalf("items: exit %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+	stdout, _, _ = runCLI([]string{"items", "--all"})
+	if strings.Contains(stdout, "Shopping list is empty") || !strings.Contains(stdout, "Bread") {
+		t.Fatalf("items --all: unexpected stdout %q", stdout)
+	}
+}
+
 func TestItemsCommandUsesExplicitList(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
+		case "/bringlists/list-2/details":
+			_, _ = w.Write([]byte("[]"))
 		case "/bringlists/list-2":
 			_ = json.NewEncoder(w).Encode(map[string]interface{}{
 				"purchase": []map[string]string{{"name": "Eggs", "specification": ""}},
 				"recently": []map[string]string{},
 			})
 		case "/bringusers/user-uuid/lists":
-			t.Fatalf("should not fetch lists when --list is provided")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"lists": []map[string]string{{"listUuid": "list-1", "name": "Groceries"}, {"listUuid": "list-2", "name": "Hardware"}},
+			})
+		case "/bringlists/list-1":
+			t.Fatalf("should use the list given by --list")
 		default:
 			w.WriteHeader(http.StatusNotFound)
 		}
@@ -132,7 +171,7 @@ func TestItemsCommandUsesExplicitList(t *testing.T) {
 		t.Fatalf("save config: %v", err)
 	}
 
-	stdout, stderr, code := runCLI([]string{"items", "--list", "list-2"})
+	stdout, stderr, code := runCLI([]string{"items", "--list", "hard"})
 	if code != 0 {
 		t.Fatalf("expected exit 0, got %d", code)
 	}
@@ -145,6 +184,12 @@ func TestItemsCommandUsesExplicitList(t *testing.T) {
 	if !strings.Contains(stdout, "Eggs") {
 		t.Fatalf("unexpected stdout: %s", stdout)
 	}
+
+	stdout, _, code = runCLI([]string{"items", "--list", "hard", "--format", "json"})
+	var items itemsOutput
+	if err := json.Unmarshal([]byte(stdout), &items); code != 0 || err != nil || items.List == "" {
+		t.Fatalf("expected the list name with --list: exit %d, %v: %s", code, err, stdout)
+	}
 }
 
 func TestLoginTokenFlowSavesConfig(t *testing.T) {
@@ -216,16 +261,15 @@ func TestAddCommandUsesDefaultList(t *testing.T) {
 			_ = json.NewEncoder(w).Encode(map[string]interface{}{
 				"lists": []map[string]string{{"listUuid": "list-1", "name": "Groceries"}},
 			})
-		case "/bringlists/list-1":
-			body, _ := io.ReadAll(r.Body)
-			values, _ := url.ParseQuery(string(body))
-			if values.Get("purchase") != "Milk" {
-				t.Fatalf("unexpected purchase: %s", values.Get("purchase"))
+		case "/bringlists/list-1/items":
+			var payload struct {
+				Changes []bring.BatchUpdateItem `json:"changes"`
 			}
-			if values.Get("specification") != "2%" {
-				t.Fatalf("unexpected spec: %s", values.Get("specification"))
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			if len(payload.Changes) != 1 || payload.Changes[0].ItemID != "Milk" || payload.Changes[0].Spec != "2%" {
+				t.Fatalf("unexpected changes: %+v", payload.Changes)
 			}
-			w.WriteHeader(http.StatusNoContent)
+			w.WriteHeader(http.StatusOK)
 		default:
 			w.WriteHeader(http.StatusNotFound)
 		}
@@ -252,19 +296,29 @@ func TestAddCommandUsesDefaultList(t *testing.T) {
 
 func TestRemoveCommandUsesExplicitList(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path != "/bringlists/list-1" {
+		switch r.URL.Path {
+		case "/bringusers/user-uuid/lists":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"lists": []map[string]string{{"listUuid": "list-1", "name": "Groceries"}},
+			})
+		case "/bringlists/list-1":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"purchase": []map[string]string{{"name": "Milk", "specification": "2 L", "uuid": "milk-1"}},
+			})
+		case "/bringlists/list-1/details":
+			_, _ = w.Write([]byte("[]"))
+		case "/bringlists/list-1/items":
+			var payload struct {
+				Changes []bring.BatchUpdateItem `json:"changes"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			if len(payload.Changes) != 1 || payload.Changes[0].UUID != "milk-1" || payload.Changes[0].Operation != bring.BringItemRemove {
+				t.Fatalf("unexpected changes: %+v", payload.Changes)
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
 			w.WriteHeader(http.StatusNotFound)
-			return
-		}
-		if r.Method != http.MethodPut {
-			t.Fatalf("unexpected method: %s", r.Method)
 		}
-		body, _ := io.ReadAll(r.Body)
-		values, _ := url.ParseQuery(string(body))
-		if values.Get("remove") != "Milk" {
-			t.Fatalf("unexpected remove: %s", values.Get("remove"))
-		}
-		w.WriteHeader(http.StatusNoContent)
 	}))
 	defer server.Close()
 
@@ -281,23 +335,36 @@ func TestRemoveCommandUsesExplicitList(t *testing.T) {
 	if stderr != "" {
 		t.Fatalf("unexpected stderr: %s", stderr)
 	}
-	if !strings.Contains(stdout, "Removed \"Milk\" from list-1") {
+	if !strings.Contains(stdout, "Removed \"Milk (2 L)\" from Groceries") {
 		t.Fatalf("unexpected stdout: %s", stdout)
 	}
 }
 
 func TestCompleteCommandUsesExplicitList(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path != "/bringlists/list-1" {
+		switch r.URL.Path {
+		case "/bringusers/user-uuid/lists":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"lists": []map[string]string{{"listUuid": "list-1", "name": "Groceries"}},
+			})
+		case "/bringlists/list-1":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"purchase": []map[string]string{{"name": "Milk", "specification": "2 L", "uuid": "milk-1"}},
+			})
+		case "/bringlists/list-1/details":
+			_, _ = w.Write([]byte("[]"))
+		case "/bringlists/list-1/items":
+			var payload struct {
+				Changes []bring.BatchUpdateItem `json:"changes"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			if len(payload.Changes) != 1 || payload.Changes[0].UUID != "milk-1" || payload.Changes[0].Operation != bring.BringItemToRecently {
+				t.Fatalf("unexpected changes: %+v", payload.Changes)
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
 			w.WriteHeader(http.StatusNotFound)
-			return
-		}
-		body, _ := io.ReadAll(r.Body)
-		values, _ := url.ParseQuery(string(body))
-		if values.Get("recently") != "Milk" {
-			t.Fatalf("unexpected recently: %s", values.Get("recently"))
 		}
-		w.WriteHeader(http.StatusNoContent)
 	}))
 	defer server.Close()
 
@@ -314,7 +381,7 @@ func TestCompleteCommandUsesExplicitList(t *testing.T) {
 	if stderr != "" {
 		t.Fatalf("unexpected stderr: %s", stderr)
 	}
-	if !strings.Contains(stdout, "Completed \"Milk\" in list-1") {
+	if !strings.Contains(stdout, "Completed \"Milk (2 L)\" in Groceries") {
 		t.Fatalf("unexpected stdout: %s", stdout)
 	}
 }
@@ -375,10 +442,28 @@ func TestAddRecipeFiltersPantryAndScales(t *testing.T) {
 	if !strings.Contains(stdout, "Added 2 ingredients from \"Pancakes\" to Groceries") {
 		t.Fatalf("unexpected stdout: %s", stdout)
 	}
+
+	stdout, _, code = runCLI([]string{"add-recipe", "recipe-1", "--servings", "4", "--format", "json"})
+	var added result
+	if err := json.Unmarshal([]byte(stdout), &added); code != 0 || err != nil {
+		t.Fatalf("add-recipe json: exit %d, %v: %s", code, err, stdout)
+	}
+	if added.Recipe != "Pancakes" || added.RecipeUUID != "recipe-1" || added.Item != "" || added.ItemUUID != "" {
+		t.Fatalf("expected the recipe in recipe fields: %+v", added)
+	}
+	if strings.HasPrefix(added.Message, "\n") {
+		t.Fatalf("unexpected leading newline in message: %q", added.Message)
+	}
 }
 
 func TestNotifyCommandSendsUrgentMessage(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/bringusers/user-uuid/lists" {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"lists": []map[string]string{{"listUuid": "list-1", "name": "Groceries"}},
+			})
+			return
+		}
 		if r.URL.Path != "/bringnotifications/lists/list-1" {
 			t.Fatalf("unexpected path: %s", r.URL.Path)
 		}
@@ -414,9 +499,15 @@ func TestNotifyCommandSendsUrgentMessage(t *testing.T) {
 	if stderr != "" {
 		t.Fatalf("unexpected stderr: %s", stderr)
 	}
-	if !strings.Contains(stdout, "Notification \"URGENT_MESSAGE\" sent to list-1") {
+	if !strings.Contains(stdout, "Notification \"URGENT_MESSAGE\" sent to Groceries") {
 		t.Fatalf("unexpected stdout: %s", stdout)
 	}
+
+	stdout, _, code = runCLI([]string{"notify", "URGENT_MESSAGE", "--message", "Milk", "--list", "list-1", "--format", "json"})
+	var notified result
+	if err := json.Unmarshal([]byte(stdout), &notified); code != 0 || err != nil || notified.Text != "Milk" || notified.Item != "" {
+		t.Fatalf("notify json: exit %d, %v: %s", code, err, stdout)
+	}
 }
 
 func TestActivityCommandOutput(t *testing.T) {
@@ -503,10 +594,18 @@ func TestRecipeCommandJSONOutput(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if strings.HasPrefix(r.URL.Path, "/bringtemplates/content/") {
 			_ = json.NewEncoder(w).Encode(map[string]interface{}{
-				"title": "Pasta",
+				"title":      "Pasta",
+				"author":     "Chef",
+				"linkOutUrl": "https://example.com/pasta",
+				"yield":      "2",
 				"nutrition": map[string]interface{}{
 					"calories": "200",
 				},
+				"items": []map[string]interface{}{
+					{"itemId": "Spaghetti", "spec": "200 g"},
+					{"itemId": "Salt", "spec": "1 tsp", "stock": true},
+				},
+				"instructions": []string{"Boil", "Serve"},
 			})
 			return
 		}
@@ -541,6 +640,31 @@ func TestRecipeCommandJSONOutput(t *testing.T) {
 	if !ok || nutrition["calories"] != "200" {
 		t.Fatalf("unexpected nutrition: %#v", payload["nutrition"])
 	}
+
+	stdout, _, code = runCLI([]string{"recipe", "recipe-1", "--servings", "4"})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d", code)
+	}
+	var recipe recipeOutput
+	if err := json.Unmarshal([]byte(stdout), &recipe); err != nil {
+		t.Fatalf("invalid json output: %v", err)
+	}
+	if recipe.SchemaVersion != recipeSchemaVersion || recipe.Author != "Chef" || recipe.SourceURL != "https://example.com/pasta" {
+		t.Fatalf("unexpected recipe: %+v", recipe)
+	}
+	if recipe.Servings != 2 || recipe.TargetServings != 4 || recipe.Scale != 2 {
+		t.Fatalf("unexpected servings: %+v", recipe)
+	}
+	want := []recipeIngredientOutput{
+		{Name: "Spaghetti", Spec: "400 g", RawSpec: "200 g"},
+		{Name: "Salt", Spec: "2 tsp", RawSpec: "1 tsp", Pantry: true},
+	}
+	if len(recipe.Ingredients) != len(want) || recipe.Ingredients[0] != want[0] || recipe.Ingredients[1] != want[1] {
+		t.Fatalf("unexpected ingredients: %+v", recipe.Ingredients)
+	}
+	if len(recipe.Instructions) != 2 || recipe.Instructions[0] != "Boil" {
+		t.Fatalf("unexpected instructions: %+v", recipe.Instructions)
+	}
 }
 
 func TestRecipeCommandImagesOutput(t *testing.T) {
@@ -803,6 +927,15 @@ func TestCatalogCommandOutput(t *testing.T) {
 	if !strings.Contains(stdout, "Catalog (en-US):") || !strings.Contains(stdout, "Dairy:") || !strings.Contains(stdout, "Milk") {
 		t.Fatalf("unexpected stdout: %s", stdout)
 	}
+
+	stdout, _, code = runCLI([]string{"catalog", "en-US", "--format", "json"})
+	var catalog catalogOutput
+	if err := json.Unmarshal([]byte(stdout), &catalog); code != 0 || err != nil {
+		t.Fatalf("catalog json: exit %d, %v: %s", code, err, stdout)
+	}
+	if catalog.Locale != "en-US" || len(catalog.Sections) != 1 || len(catalog.Sections[0].Items) != 2 || catalog.Sections[0].Items[0].Name != "Milk" {
+		t.Fatalf("unexpected catalog: %+v", catalog)
+	}
 }
 
 func TestAddCommandHandlesAPIError(t *testing.T) {
@@ -812,7 +945,7 @@ func TestAddCommandHandlesAPIError(t *testing.T) {
 			_ = json.NewEncoder(w).Encode(map[string]interface{}{
 				"lists": []map[string]string{{"listUuid": "list-1", "name": "Groceries"}},
 			})
-		case "/bringlists/list-1":
+		case "/bringlists/list-1/items":
 			w.WriteHeader(http.StatusBadRequest)
 			_ = json.NewEncoder(w).Encode(map[string]interface{}{
 				"error":   "invalid_item",
@@ -844,6 +977,12 @@ func TestAddCommandHandlesAPIError(t *testing.T) {
 
 func TestNotifyHandlesAPIError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/bringusers/user-uuid/lists" {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"lists": []map[string]string{{"listUuid": "list-1", "name": "Groceries"}},
+			})
+			return
+		}
 		if r.URL.Path != "/bringnotifications/lists/list-1" {
 			w.WriteHeader(http.StatusNotFound)
 			return
@@ -992,3 +1131,1053 @@ func containsString(values []string, target string) bool {
 	}
 	return false
 }
+
+func TestRefreshedTokenIsSaved(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/bringauth/token":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token":  "fresh-token",
+				"refresh_token": "fresh-refresh",
+			})
+		case "/bringusers/user-uuid/lists":
+			if r.Header.Get("Authorization") != "Bearer fresh-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"lists": []map[string]string{{"listUuid": "list-1", "name": "Groceries"}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("BRINGS_BASE_URL", server.URL)
+	if err := saveConfig(Config{AccessToken: "token", RefreshToken: "refresh", UserUUID: "user-uuid", Servings: 2}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	stdout, stderr, code := runCLI([]string{"lists"})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d (stderr: %s)", code, stderr)
+	}
+	if !strings.Contains(stdout, "Groceries (list-1)") {
+		t.Fatalf("unexpected stdout: %s", stdout)
+	}
+	config := loadConfig()
+	if config.AccessToken != "fresh-token" || config.RefreshToken != "fresh-refresh" {
+		t.Fatalf("refreshed tokens not saved: %#v", config)
+	}
+	if config.Servings != 2 {
+		t.Fatalf("other config values lost")
+	}
+}
+
+func TestDeleteDefaultListKeepsRefreshedToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/bringauth/token" {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token":  "fresh-token",
+				"refresh_token": "fresh-refresh",
+			})
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer fresh-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/bringusers/user-uuid/lists":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"lists": []map[string]string{{"listUuid": "list-1", "name": "Groceries"}},
+			})
+		case r.Method == http.MethodDelete && r.URL.Path == "/bringusers/user-uuid/lists/list-1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("BRINGS_BASE_URL", server.URL)
+	if err := saveConfig(Config{AccessToken: "token", RefreshToken: "refresh", UserUUID: "user-uuid", DefaultList: "Groceries"}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	if _, stderr, code := runCLI([]string{"lists", "delete", "Groceries", "--yes"}); code != 0 {
+		t.Fatalf("delete: exit %d: %s", code, stderr)
+	}
+	config := loadConfig()
+	if config.DefaultList != "" {
+		t.Fatalf("expected the default list to be cleared: %#v", config)
+	}
+	if config.AccessToken != "fresh-token" || config.RefreshToken != "fresh-refresh" {
+		t.Fatalf("refreshed tokens overwritten: %#v", config)
+	}
+}
+
+func TestAPIErrorsMapToExitCodes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/bringusers/user-uuid/lists":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"lists": []map[string]string{{"listUuid": "list-1", "name": "Groceries"}, {"listUuid": "list-2", "name": "Hardware"}},
+			})
+		case "/bringlists/list-1":
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"error": "invalid_token", "message": "Token expired"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("BRINGS_BASE_URL", server.URL)
+	if err := saveConfig(Config{AccessToken: "token", UserUUID: "user-uuid"}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	_, stderr, code := runCLI([]string{"items", "--list", "list-1"})
+	if code != exitUnauthorized {
+		t.Fatalf("expected exit %d, got %d", exitUnauthorized, code)
+	}
+	if !strings.Contains(stderr, "Token expired") || !strings.Contains(stderr, "brings login") {
+		t.Fatalf("unexpected stderr: %s", stderr)
+	}
+
+	_, stderr, code = runCLI([]string{"items", "--list", "list-2"})
+	if code != exitNotFound {
+		t.Fatalf("expected exit %d, got %d", exitNotFound, code)
+	}
+	if !strings.Contains(stderr, "Hint:") {
+		t.Fatalf("unexpected stderr: %s", stderr)
+	}
+}
+
+func TestAddAndCompleteAgainstFakeServer(t *testing.T) {
+	server := bringtest.NewServer()
+	defer server.Close()
+	listUUID := server.AddList("Groceries")
+	account := server.Account()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("BRINGS_BASE_URL", server.URL)
+	if err := saveConfig(Config{AccessToken: account.AccessToken, UserUUID: account.UUID}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	if _, stderr, code := runCLI([]string{"add", "Milk", "--spec", "2 L"}); code != 0 {
+		t.Fatalf("add: expected exit 0, got %d: %s", code, stderr)
+	}
+	if _, stderr, code := runCLI([]string{"complete", "Milk"}); code != 0 {
+		t.Fatalf("complete: expected exit 0, got %d: %s", code, stderr)
+	}
+
+	if purchase := server.Purchase(listUUID); len(purchase) != 0 {
+		t.Fatalf("expected empty purchase section, got %+v", purchase)
+	}
+	recently := server.Recently(listUUID)
+	if len(recently) != 1 || recently[0].ItemID != "Milk" || recently[0].Spec != "2 L" {
+		t.Fatalf("unexpected recent items: %+v", recently)
+	}
+}
+
+func TestListsCommandReplaysCassette(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("BRINGS_BASE_URL", "http://127.0.0.1:1")
+	if err := saveConfig(Config{AccessToken: "token", UserUUID: "user-uuid"}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	cassette := bring.Cassette{Version: 1, Interactions: []bring.Interaction{{
+		Request:  bring.RecordedRequest{Method: http.MethodGet, URL: "http://127.0.0.1:1/bringusers/user-uuid/lists"},
+		Response: bring.RecordedResponse{Status: http.StatusOK, Body: `{"lists":[{"listUuid":"list-1","name":"Replayed"}]}`},
+	}}}
+	path := dir + "/session.json"
+	if err := cassette.Save(path); err != nil {
+		t.Fatalf("save cassette: %v", err)
+	}
+
+	t.Setenv("BRINGS_REPLAY", path)
+	stdout, stderr, code := runCLI([]string{"lists"})
+	if code != 0 {
+		t.Fatalf("expected exit 0, got %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "Replayed") {
+		t.Fatalf("unexpected stdout: %s", stdout)
+	}
+
+	t.Setenv("BRINGS_REPLAY", dir+"/missing.json")
+	_, stderr, code = runCLI([]string{"lists"})
+	if code != 1 || !strings.Contains(stderr, "cannot read cassette") {
+		t.Fatalf("expected cassette error, got %d: %s", code, stderr)
+	}
+}
+
+func TestListsLifecycleCommands(t *testing.T) {
+	server := bringtest.NewServer()
+	defer server.Close()
+	account := server.Account()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("BRINGS_BASE_URL", server.URL)
+	if err := saveConfig(Config{AccessToken: account.AccessToken, UserUUID: account.UUID}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	stdout, stderr, code := runCLI([]string{"lists", "create", "Summer", "Party", "--theme", "bbq"})
+	if code != 0 || !strings.Contains(stdout, "Created list \"Summer Party\"") {
+		t.Fatalf("create: exit %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+	lists := server.Lists()
+	if len(lists) != 1 || lists[0].Theme != bring.ThemeBBQ {
+		t.Fatalf("unexpected lists after create: %+v", lists)
+	}
+
+	if _, stderr, code := runCLI([]string{"lists", "rename", "summer party", "Garden"}); code != 0 {
+		t.Fatalf("rename: exit %d: %s", code, stderr)
+	}
+	if _, stderr, code := runCLI([]string{"lists", "theme", "Garden", "holiday"}); code != 0 {
+		t.Fatalf("theme: exit %d: %s", code, stderr)
+	}
+	lists = server.Lists()
+	if lists[0].Name != "Garden" || lists[0].Theme != bring.ThemeHoliday {
+		t.Fatalf("unexpected lists after update: %+v", lists)
+	}
+
+	if _, stderr, code := runCLI([]string{"lists", "theme", "Garden", "beach"}); code != 1 || !strings.Contains(stderr, "unknown theme") {
+		t.Fatalf("expected an unknown theme to fail, got %d: %s", code, stderr)
+	}
+	if _, stderr, code := runCLI([]string{"lists", "create", "Beach", "--theme", "beach"}); code != 1 || !strings.Contains(stderr, "unknown theme") {
+		t.Fatalf("expected create with an unknown theme to fail, got %d: %s", code, stderr)
+	}
+	if lists := server.Lists(); len(lists) != 1 || lists[0].Theme != bring.ThemeHoliday {
+		t.Fatalf("expected unknown themes not to reach the API: %+v", lists)
+	}
+
+	withStdin(t, "n\n", func() {
+		_, stderr, code = runCLI([]string{"lists", "delete", "Garden"})
+	})
+	if code != 1 || !strings.Contains(stderr, "Aborted") || len(server.Lists()) != 1 {
+		t.Fatalf("expected delete to be aborted, got exit %d: %s", code, stderr)
+	}
+
+	withStdin(t, "y\n", func() {
+		stdout, stderr, code = runCLI([]string{"lists", "delete", "Garden"})
+	})
+	if code != 0 || !strings.Contains(stdout, "Deleted list \"Garden\"") || len(server.Lists()) != 0 {
+		t.Fatalf("delete: exit %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+
+	_, _, code = runCLI([]string{"lists", "delete", "Garden", "--yes"})
+	if code != exitNotFound {
+		t.Fatalf("expected exit %d for unknown list, got %d", exitNotFound, code)
+	}
+}
+
+func withStdin(t *testing.T, input string, fn func()) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	_, _ = w.WriteString(input)
+	_ = w.Close()
+
+	oldStdin := os.Stdin
+	os.Stdin = r
+	defer func() {
+		os.Stdin = oldStdin
+		_ = r.Close()
+	}()
+	fn()
+}
+
+func TestInvitesCommands(t *testing.T) {
+	server := bringtest.NewServer()
+	defer server.Close()
+	listUUID := server.AddList("Home")
+	flat := server.AddInvitation("Flat", "Alex", "alex@example.com")
+	office := server.AddInvitation("Office", "Sam", "sam@example.com")
+	account := server.Account()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("BRINGS_BASE_URL", server.URL)
+	if err := saveConfig(Config{AccessToken: account.AccessToken, UserUUID: account.UUID}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	stdout, stderr, code := runCLI([]string{"invites"})
+	if code != 0 || !strings.Contains(stdout, flat) || !strings.Contains(stdout, "\"Office\" from Sam <sam@example.com>") {
+		t.Fatalf("invites: exit %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+
+	stdout, _, code = runCLI([]string{"invites", "--format", "json"})
+	var invites invitesOutput
+	if err := json.Unmarshal([]byte(stdout), &invites); code != 0 || err != nil || len(invites.Invitations) != 2 {
+		t.Fatalf("invites json: exit %d, %v: %s", code, err, stdout)
+	}
+	if first := invites.Invitations[0]; first.InvitationUUID != flat || first.List != "Flat" || first.Inviter != "Alex" {
+		t.Fatalf("unexpected invitation: %+v", first)
+	}
+	if strings.Contains(stdout, `"raw"`) || strings.Contains(stdout, "0001-01-01") {
+		t.Fatalf("expected no raw payload or zero time: %s", stdout)
+	}
+
+	if _, stderr, code := runCLI([]string{"invites", "accept", "flat"}); code != 0 {
+		t.Fatalf("accept: exit %d: %s", code, stderr)
+	}
+	if _, stderr, code := runCLI([]string{"invites", "decline", office}); code != 0 {
+		t.Fatalf("decline: exit %d: %s", code, stderr)
+	}
+	if server.InvitationStatus(flat) != "ACCEPTED" || server.InvitationStatus(office) != "DECLINED" {
+		t.Fatalf("unexpected statuses: %s, %s", server.InvitationStatus(flat), server.InvitationStatus(office))
+	}
+	if lists := server.Lists(); len(lists) != 2 || lists[1].Name != "Flat" {
+		t.Fatalf("expected accepted list to be joined, got %+v", lists)
+	}
+
+	stdout, _, _ = runCLI([]string{"invites"})
+	if !strings.Contains(stdout, "No pending invitations") {
+		t.Fatalf("unexpected stdout: %s", stdout)
+	}
+
+	stdout, stderr, code = runCLI([]string{"users", "invite", "flatmate@example.com", "--list", listUUID})
+	if code != 0 || !strings.Contains(stdout, "Invited flatmate@example.com") {
+		t.Fatalf("users invite: exit %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+	sent := server.SentInvitations()
+	if len(sent) != 1 || sent[0].ListUUID != listUUID || sent[0].Email != "flatmate@example.com" {
+		t.Fatalf("unexpected sent invitations: %+v", sent)
+	}
+}
+
+func TestItemAttributeFlags(t *testing.T) {
+	server := bringtest.NewServer()
+	defer server.Close()
+	listUUID := server.AddList("Groceries")
+	server.AddItem(listUUID, "Bread", "")
+	account := server.Account()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("BRINGS_BASE_URL", server.URL)
+	if err := saveConfig(Config{AccessToken: account.AccessToken, UserUUID: account.UUID}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	stdout, stderr, code := runCLI([]string{"add", "Milk", "--urgent", "--spec", "2 L"})
+	if code != 0 || !strings.Contains(stdout, "[urgent]") {
+		t.Fatalf("add: exit %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+	stdout, _, _ = runCLI([]string{"items"})
+	if !strings.Contains(stdout, "2. Milk (2 L) [urgent]") || !strings.Contains(stdout, "1. Bread\n") {
+		t.Fatalf("unexpected items output: %s", stdout)
+	}
+
+	stdout, stderr, code = runCLI([]string{"flag", "milk", "--urgent=false", "--discounted"})
+	if code != 0 || !strings.Contains(stdout, "Flagged \"Milk\" in Groceries: discounted") {
+		t.Fatalf("flag: exit %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+	purchase := server.Purchase(listUUID)
+	if len(purchase) != 2 || purchase[1].ItemID != "Milk" || purchase[1].Attributes != (bring.ItemAttributes{Discounted: true}) || purchase[1].Spec != "2 L" {
+		t.Fatalf("unexpected purchase items: %+v", purchase)
+	}
+
+	if _, stderr, code := runCLI([]string{"flag", "Milk", "--urgent=maybe"}); code != 1 || !strings.Contains(stderr, "invalid value for --urgent") {
+		t.Fatalf("expected invalid flag error, got %d: %s", code, stderr)
+	}
+	if _, _, code := runCLI([]string{"flag", "Cheese", "--urgent"}); code != exitNotFound {
+		t.Fatalf("expected exit %d for missing item, got %d", exitNotFound, code)
+	}
+}
+
+func TestImageCommands(t *testing.T) {
+	server := bringtest.NewServer()
+	defer server.Close()
+	listUUID := server.AddList("Groceries")
+	server.AddItem(listUUID, "Coffee", "")
+	account := server.Account()
+
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("BRINGS_BASE_URL", server.URL)
+	if err := saveConfig(Config{AccessToken: account.AccessToken, UserUUID: account.UUID}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 8, 8))); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	photo := filepath.Join(dir, "coffee.png")
+	if err := os.WriteFile(photo, buf.Bytes(), 0o644); err != nil {
+		t.Fatalf("write photo: %v", err)
+	}
+
+	stdout, stderr, code := runCLI([]string{"image", "set", "coffee", photo})
+	if code != 0 || !strings.Contains(stdout, "Set image of \"Coffee\"") {
+		t.Fatalf("image set: exit %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+	if purchase := server.Purchase(listUUID); purchase[0].ImageURL == "" {
+		t.Fatalf("expected image to be stored: %+v", purchase)
+	}
+
+	if _, stderr, code := runCLI([]string{"image", "rm", "Coffee"}); code != 0 {
+		t.Fatalf("image rm: exit %d: %s", code, stderr)
+	}
+	if purchase := server.Purchase(listUUID); purchase[0].ImageURL != "" {
+		t.Fatalf("expected image to be removed: %+v", purchase)
+	}
+
+	notes := filepath.Join(dir, "notes.txt")
+	_ = os.WriteFile(notes, []byte("just text"), 0o644)
+	if _, stderr, code := runCLI([]string{"image", "set", "Coffee", notes}); code != 1 || !strings.Contains(stderr, "unsupported image format") {
+		t.Fatalf("expected unsupported format error, got %d: %s", code, stderr)
+	}
+	if _, _, code := runCLI([]string{"image", "rm", "Tea"}); code != exitNotFound {
+		t.Fatalf("expected exit %d for missing item, got %d", exitNotFound, code)
+	}
+}
+
+func TestItemCommandsRequireItemUUID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/bringusers/user-uuid/lists":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"lists": []map[string]string{{"listUuid": "list-1", "name": "Groceries"}},
+			})
+		case "/bringlists/list-1":
+			_, _ = w.Write([]byte(`{"purchase":[{"name":"Coffee"}],"recently":[]}`))
+		case "/bringlists/list-1/details":
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("BRINGS_BASE_URL", server.URL)
+	if err := saveConfig(Config{AccessToken: "token", UserUUID: "user-uuid"}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	if _, stderr, code := runCLI([]string{"image", "rm", "Coffee"}); code != 1 || !strings.Contains(stderr, "has no UUID") {
+		t.Fatalf("image rm: expected a missing UUID error, got %d: %s", code, stderr)
+	}
+	if _, stderr, code := runCLI([]string{"assign", "Coffee", "--clear"}); code != 1 || !strings.Contains(stderr, "has no UUID") {
+		t.Fatalf("assign: expected a missing UUID error, got %d: %s", code, stderr)
+	}
+}
+
+func TestItemsCommandShowsDetails(t *testing.T) {
+	server := bringtest.NewServer()
+	defer server.Close()
+	listUUID := server.AddList("Groceries")
+	server.AddMember(listUUID, bring.GetAllUsersFromListEntry{PublicUUID: "public-2", Name: "Alex"})
+	milk := server.AddItem(listUUID, "Milk", "2 L")
+	server.AddItem(listUUID, "Bread", "")
+	server.UpdateItem(listUUID, milk, func(item *bringtest.Item) {
+		item.AssignedTo = "public-2"
+		item.SectionID = "Dairy"
+		item.ImageURL = "https://example.com/milk.jpg"
+	})
+	account := server.Account()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("BRINGS_BASE_URL", server.URL)
+	if err := saveConfig(Config{AccessToken: account.AccessToken, UserUUID: account.UUID}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	stdout, stderr, code := runCLI([]string{"items"})
+	if code != 0 {
+		t.Fatalf("items: exit %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "Milk (2 L) - assigned to Alex, section Dairy, photo") {
+		t.Fatalf("unexpected stdout: %s", stdout)
+	}
+	if !strings.Contains(stdout, "  2. Bread\n") {
+		t.Fatalf("expected bread without details: %s", stdout)
+	}
+
+	stdout, stderr, code = runCLI([]string{"items", "--format", "json"})
+	if code != 0 {
+		t.Fatalf("items json: exit %d: %s", code, stderr)
+	}
+	var output struct {
+		ListUUID string `json:"listUuid"`
+		Purchase []struct {
+			UUID       string `json:"uuid"`
+			ItemID     string `json:"itemId"`
+			AssignedTo string `json:"assignedTo"`
+			Assignee   string `json:"assignee"`
+			SectionID  string `json:"sectionId"`
+		} `json:"purchase"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &output); err != nil {
+		t.Fatalf("invalid json %q: %v", stdout, err)
+	}
+	if output.ListUUID != listUUID || len(output.Purchase) != 2 {
+		t.Fatalf("unexpected output: %+v", output)
+	}
+	if got := output.Purchase[0]; got.UUID != milk || got.Assignee != "Alex" || got.SectionID != "Dairy" {
+		t.Fatalf("unexpected milk entry: %+v", got)
+	}
+}
+
+func TestAssignCommandAndFilters(t *testing.T) {
+	server := bringtest.NewServer()
+	defer server.Close()
+	listUUID := server.AddList("Groceries")
+	account := server.Account()
+	server.AddMember(listUUID, bring.GetAllUsersFromListEntry{PublicUUID: "public-2", Name: "Alex", Email: "alex@example.com"})
+	server.AddItem(listUUID, "Milk", "")
+	server.AddItem(listUUID, "Nails", "")
+	server.AddItem(listUUID, "Bread", "")
+	server.AddRecent(listUUID, "Butter", "")
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("BRINGS_BASE_URL", server.URL)
+	if err := saveConfig(Config{AccessToken: account.AccessToken, UserUUID: account.UUID, PublicUserUUID: account.PublicUUID}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	if stdout, stderr, code := runCLI([]string{"assign", "milk", "tester"}); code != 0 || !strings.Contains(stdout, "Assigned \"Milk\" to Tester") {
+		t.Fatalf("assign: exit %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+	if _, stderr, code := runCLI([]string{"assign", "Nails", "ALEX@example.com"}); code != 0 {
+		t.Fatalf("assign by email: exit %d: %s", code, stderr)
+	}
+	if _, stderr, code := runCLI([]string{"assign", "Bread", "Sam"}); code != 4 || !strings.Contains(stderr, "no member") {
+		t.Fatalf("expected unknown member to fail with 4, got %d: %s", code, stderr)
+	}
+	if _, stderr, code := runCLI([]string{"assign", "Butter", "Alex"}); code != exitNotFound || !strings.Contains(stderr, `"Butter" is not on Groceries`) {
+		t.Fatalf("expected a recently purchased item not to be assignable, got %d: %s", code, stderr)
+	}
+
+	stdout, _, code := runCLI([]string{"items", "--mine"})
+	if code != 0 || !strings.Contains(stdout, "Milk") || strings.Contains(stdout, "Nails") || strings.Contains(stdout, "Bread") {
+		t.Fatalf("items --mine: exit %d: %s", code, stdout)
+	}
+	stdout, _, code = runCLI([]string{"items", "--assignee", "Alex"})
+	if code != 0 || !strings.Contains(stdout, "Nails - assigned to Alex") || strings.Contains(stdout, "Milk") {
+		t.Fatalf("items --assignee: exit %d: %s", code, stdout)
+	}
+
+	if _, stderr, code := runCLI([]string{"assign", "Nails", "--clear"}); code != 0 {
+		t.Fatalf("assign --clear: exit %d: %s", code, stderr)
+	}
+	for _, item := range server.Purchase(listUUID) {
+		if item.ItemID == "Nails" && item.AssignedTo != "" {
+			t.Fatalf("expected assignment to be cleared: %+v", item)
+		}
+	}
+	stdout, _, _ = runCLI([]string{"items", "--assignee", "alex"})
+	if !strings.Contains(stdout, "No items assigned to Alex") {
+		t.Fatalf("unexpected stdout: %s", stdout)
+	}
+}
+
+func TestListResolutionOrder(t *testing.T) {
+	server := bringtest.NewServer()
+	defer server.Close()
+	groceries := server.AddList("Groceries")
+	garden := server.AddList("Garden")
+	hardware := server.AddList("Hardware")
+	account := server.Account()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("BRINGS_BASE_URL", server.URL)
+	if err := saveConfig(Config{AccessToken: account.AccessToken, UserUUID: account.UUID}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	listOf := func(args ...string) string {
+		t.Helper()
+		stdout, stderr, code := runCLI(append([]string{"items", "--format", "json"}, args...))
+		if code != 0 {
+			t.Fatalf("items %v: exit %d: %s", args, code, stderr)
+		}
+		var output struct {
+			ListUUID string `json:"listUuid"`
+		}
+		if err := json.Unmarshal([]byte(stdout), &output); err != nil {
+			t.Fatalf("invalid json %q: %v", stdout, err)
+		}
+		return output.ListUUID
+	}
+
+	if got := listOf(); got != groceries {
+		t.Fatalf("expected first list, got %s", got)
+	}
+	if _, _, code := runCLI([]string{"config", "defaultList", "garden"}); code != 0 {
+		t.Fatalf("config defaultList failed")
+	}
+	if got := listOf(); got != garden {
+		t.Fatalf("expected defaultList, got %s", got)
+	}
+	t.Setenv("BRINGS_LIST", "Hard")
+	if got := listOf(); got != hardware {
+		t.Fatalf("expected BRINGS_LIST, got %s", got)
+	}
+	if got := listOf("--list", groceries); got != groceries {
+		t.Fatalf("expected --list, got %s", got)
+	}
+
+	_, stderr, code := runCLI([]string{"items", "--list", "g"})
+	if code != 1 || !strings.Contains(stderr, "Groceries ("+groceries+")") || !strings.Contains(stderr, "Garden ("+garden+")") {
+		t.Fatalf("expected ambiguous match to list candidates, got %d: %s", code, stderr)
+	}
+	if _, stderr, code := runCLI([]string{"items", "--list", "Bakery"}); code != 4 || !strings.Contains(stderr, "--list") {
+		t.Fatalf("expected unknown list to fail with 4, got %d: %s", code, stderr)
+	}
+
+	otherHardware := server.AddList("Hardware")
+	_, stderr, code = runCLI([]string{"items", "--list", "Hardware"})
+	if code != 1 || !strings.Contains(stderr, "Hardware ("+hardware+")") || !strings.Contains(stderr, "Hardware ("+otherHardware+")") {
+		t.Fatalf("expected duplicate name to list candidates, got %d: %s", code, stderr)
+	}
+	if got := listOf("--list", otherHardware); got != otherHardware {
+		t.Fatalf("expected UUID to pick the list, got %s", got)
+	}
+}
+
+func TestUnknownFlagsAndCommandHelp(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := saveConfig(Config{AccessToken: "token", UserUUID: "user-uuid"}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	stdout, stderr, code := runCLI([]string{"add", "Milk", "--sepc", "2%"})
+	if code != 1 || stdout != "" || !strings.Contains(stderr, "unknown flag --sepc") || !strings.Contains(stderr, "--spec") {
+		t.Fatalf("expected unknown flag error, got %d %q %q", code, stdout, stderr)
+	}
+
+	_, stderr, code = runCLI([]string{"itmes"})
+	if code != 1 || !strings.Contains(stderr, "Did you mean: items?") {
+		t.Fatalf("expected command suggestion, got %d %q", code, stderr)
+	}
+
+	for _, args := range [][]string{{"help", "add"}, {"add", "--help"}} {
+		stdout, stderr, code := runCLI(args)
+		if code != 0 || stderr != "" {
+			t.Fatalf("%v: exit %d: %s", args, code, stderr)
+		}
+		for _, want := range []string{"Usage: brings add <item[:spec]>... | - [flags]", "--spec <text>", "--list <list>", "--urgent", "-h, --help"} {
+			if !strings.Contains(stdout, want) {
+				t.Fatalf("%v: expected %q in help:\n%s", args, want, stdout)
+			}
+		}
+	}
+	if stdout, _, _ := runCLI([]string{"help", "rm"}); !strings.Contains(stdout, "brings remove <item|index|uuid>") || !strings.Contains(stdout, "Aliases: rm") {
+		t.Fatalf("expected alias to resolve: %s", stdout)
+	}
+}
+
+func TestJSONFormatOnEveryCommand(t *testing.T) {
+	server := bringtest.NewServer()
+	defer server.Close()
+	listUUID := server.AddList("Groceries")
+	account := server.Account()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("BRINGS_BASE_URL", server.URL)
+	if err := saveConfig(Config{AccessToken: account.AccessToken, UserUUID: account.UUID}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	stdout, stderr, code := runCLI([]string{"add", "Milk", "--spec", "2 L", "--urgent", "--format", "json"})
+	if code != 0 {
+		t.Fatalf("add: exit %d: %s", code, stderr)
+	}
+	var added result
+	if err := json.Unmarshal([]byte(stdout), &added); err != nil {
+		t.Fatalf("add output is not JSON: %v: %s", err, stdout)
+	}
+	if added.Action != "add" || added.ListUUID != listUUID || added.Item != "Milk" || added.Spec != "2 L" || added.Attributes == nil || !added.Attributes.Urgent {
+		t.Fatalf("unexpected add result: %+v", added)
+	}
+
+	stdout, _, code = runCLI([]string{"complete", "Milk", "--format=json"})
+	var completed result
+	if err := json.Unmarshal([]byte(stdout), &completed); code != 0 || err != nil || completed.Action != "complete" || completed.List != "Groceries" {
+		t.Fatalf("complete: exit %d, %v: %s", code, err, stdout)
+	}
+
+	stdout, _, code = runCLI([]string{"lists", "--format", "json"})
+	var lists listsOutput
+	if err := json.Unmarshal([]byte(stdout), &lists); code != 0 || err != nil || len(lists.Lists) != 1 || lists.Lists[0].ListUUID != listUUID {
+		t.Fatalf("lists: exit %d, %v: %s", code, err, stdout)
+	}
+
+	server.SetUserSettings(bring.GetUserSettingsResponse{
+		UserSettings:     []bring.UserSettingsEntry{{Key: "defaultListUUID", Value: listUUID}},
+		UserListSettings: []bring.UserListSettingsEntry{{ListUUID: listUUID, UserSettings: []bring.UserSettingsEntry{{Key: "listArticleLanguage", Value: "de-CH"}}}},
+	})
+	stdout, _, code = runCLI([]string{"settings", "--format", "json"})
+	var settings settingsOutput
+	if err := json.Unmarshal([]byte(stdout), &settings); code != 0 || err != nil || settings.Settings["defaultListUUID"] != listUUID ||
+		len(settings.Lists) != 1 || settings.Lists[0].Settings["listArticleLanguage"] != "de-CH" {
+		t.Fatalf("settings: exit %d, %v: %s", code, err, stdout)
+	}
+
+	stdout, _, code = runCLI([]string{"users", "--format", "json"})
+	var users usersOutput
+	if err := json.Unmarshal([]byte(stdout), &users); code != 0 || err != nil || users.List != "Groceries" || len(users.Users) != 1 {
+		t.Fatalf("users: exit %d, %v: %s", code, err, stdout)
+	}
+
+	if _, stderr, code := runCLI([]string{"config", "servings", "4", "--format", "json"}); code != 0 {
+		t.Fatalf("config set: exit %d: %s", code, stderr)
+	}
+	stdout, _, code = runCLI([]string{"config", "--format", "json"})
+	var cfg configOutput
+	if err := json.Unmarshal([]byte(stdout), &cfg); code != 0 || err != nil || cfg.Servings != 4 || cfg.Path == "" {
+		t.Fatalf("config: exit %d, %v: %s", code, err, stdout)
+	}
+
+	stdout, _, code = runCLI([]string{"status", "--format", "pretty"})
+	var status statusOutput
+	if err := json.Unmarshal([]byte(stdout), &status); code != 0 || err != nil || !status.LoggedIn || !strings.Contains(stdout, "\n  ") {
+		t.Fatalf("status: exit %d, %v: %s", code, err, stdout)
+	}
+
+	if _, stderr, code := runCLI([]string{"lists", "--format", "xml"}); code != 1 || !strings.Contains(stderr, "unknown format") {
+		t.Fatalf("expected invalid format to fail, got %d: %s", code, stderr)
+	}
+}
+
+func TestItemAddressingWithDuplicateNames(t *testing.T) {
+	server := bringtest.NewServer()
+	defer server.Close()
+	listUUID := server.AddList("Groceries")
+	server.AddItem(listUUID, "Milk", "2 L")
+	server.AddItem(listUUID, "Bread", "")
+	oatUUID := server.AddItem(listUUID, "Milk", "oat")
+	server.AddItem(listUUID, "Milk", "soy")
+	account := server.Account()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("BRINGS_BASE_URL", server.URL)
+	if err := saveConfig(Config{AccessToken: account.AccessToken, UserUUID: account.UUID}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	stdout, _, _ := runCLI([]string{"items"})
+	if !strings.Contains(stdout, "1. Milk (2 L)") || !strings.Contains(stdout, "3. Milk (oat)") {
+		t.Fatalf("expected indexed items: %s", stdout)
+	}
+
+	_, stderr, code := runCLI([]string{"complete", "milk"})
+	if code != 1 || !strings.Contains(stderr, "matches several items") || !strings.Contains(stderr, "3. Milk (oat)") {
+		t.Fatalf("expected ambiguity error, got %d: %s", code, stderr)
+	}
+	if len(server.Purchase(listUUID)) != 4 {
+		t.Fatalf("ambiguous complete must not change the list: %+v", server.Purchase(listUUID))
+	}
+
+	if _, stderr, code := runCLI([]string{"complete", "Milk", "--spec", "OAT"}); code != 0 {
+		t.Fatalf("complete --spec: exit %d: %s", code, stderr)
+	}
+	if recently := server.Recently(listUUID); len(recently) != 1 || recently[0].UUID != oatUUID {
+		t.Fatalf("expected oat milk to be completed: %+v", recently)
+	}
+
+	if _, stderr, code := runCLI([]string{"remove", "1"}); code != 0 {
+		t.Fatalf("remove by index: exit %d: %s", code, stderr)
+	}
+	purchase := server.Purchase(listUUID)
+	if len(purchase) != 2 || purchase[0].ItemID != "Bread" || purchase[1].Spec != "soy" {
+		t.Fatalf("expected the first milk to be removed: %+v", purchase)
+	}
+
+	if _, stderr, code := runCLI([]string{"remove", purchase[1].UUID}); code != 0 {
+		t.Fatalf("remove by UUID: exit %d: %s", code, stderr)
+	}
+	if _, stderr, code := runCLI([]string{"remove", "Milk", "--spec", "rice"}); code != 4 {
+		t.Fatalf("expected unknown spec to fail with 4, got %d: %s", code, stderr)
+	}
+	if purchase := server.Purchase(listUUID); len(purchase) != 1 || purchase[0].ItemID != "Bread" {
+		t.Fatalf("unexpected purchase: %+v", purchase)
+	}
+}
+
+func TestEditCommand(t *testing.T) {
+	server := bringtest.NewServer()
+	defer server.Close()
+	listUUID := server.AddList("Groceries")
+	milkUUID := server.AddItem(listUUID, "Milk", "2 L")
+	server.AddItem(listUUID, "Bread", "")
+	account := server.Account()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("BRINGS_BASE_URL", server.URL)
+	if err := saveConfig(Config{AccessToken: account.AccessToken, UserUUID: account.UUID}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	stdout, stderr, code := runCLI([]string{"edit", "milk", "--spec", "3 L"})
+	if code != 0 || !strings.Contains(stdout, `Changed "Milk (2 L)" to "Milk (3 L)" in Groceries`) {
+		t.Fatalf("edit: exit %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+	if _, stderr, code := runCLI([]string{"edit", "Milk", "--spec", "lactose free", "--append"}); code != 0 {
+		t.Fatalf("edit --append: exit %d: %s", code, stderr)
+	}
+	if _, stderr, code := runCLI([]string{"edit", "Bread", "--spec", "whole grain", "--append"}); code != 0 {
+		t.Fatalf("edit --append on empty spec: exit %d: %s", code, stderr)
+	}
+	for _, item := range server.Purchase(listUUID) {
+		switch item.ItemID {
+		case "Milk":
+			if item.UUID != milkUUID || item.Spec != "3 L, lactose free" {
+				t.Fatalf("expected the spec to be updated in place: %+v", item)
+			}
+		case "Bread":
+			if item.Spec != "whole grain" {
+				t.Fatalf("unexpected bread: %+v", item)
+			}
+		}
+	}
+	if _, stderr, code := runCLI([]string{"flag", "Milk", "--urgent"}); code != 0 {
+		t.Fatalf("flag: exit %d: %s", code, stderr)
+	}
+	stdout, stderr, code = runCLI([]string{"edit", "Milk", "--name", "Oat milk", "--format", "json"})
+	if code != 0 {
+		t.Fatalf("edit --name: exit %d: %s", code, stderr)
+	}
+	var edited result
+	if err := json.Unmarshal([]byte(stdout), &edited); err != nil || edited.Action != "edit" || edited.Previous != "Milk (3 L, lactose free)" || edited.Item != "Oat milk" {
+		t.Fatalf("unexpected result: %v: %s", err, stdout)
+	}
+	var replaced *bringtest.Item
+	for _, item := range server.Purchase(listUUID) {
+		if item.ItemID == "Milk" {
+			t.Fatalf("expected Milk to be replaced: %+v", server.Purchase(listUUID))
+		}
+		if item.ItemID == "Oat milk" {
+			replaced = &item
+		}
+	}
+	if replaced == nil || replaced.Spec != "3 L, lactose free" || !replaced.Attributes.Urgent {
+		t.Fatalf("expected spec and attributes to carry over: %+v", replaced)
+	}
+
+	if _, stderr, code := runCLI([]string{"edit", "Bread", "--spec", ""}); code != 0 {
+		t.Fatalf("clearing the spec: exit %d: %s", code, stderr)
+	}
+	if _, _, code := runCLI([]string{"edit", "Bread"}); code != 1 {
+		t.Fatalf("expected edit without changes to fail, got %d", code)
+	}
+}
+
+func TestBulkItemCommands(t *testing.T) {
+	server := bringtest.NewServer()
+	defer server.Close()
+	listUUID := server.AddList("Groceries")
+	server.AddItem(listUUID, "Milk", "2 L")
+	server.AddItem(listUUID, "Milk", "oat")
+	account := server.Account()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("BRINGS_BASE_URL", server.URL)
+	if err := saveConfig(Config{AccessToken: account.AccessToken, UserUUID: account.UUID}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	batches := func() int {
+		count := 0
+		for _, request := range server.Requests() {
+			if request.Method == http.MethodPut && strings.HasSuffix(request.Path, "/items") {
+				count++
+			}
+		}
+		return count
+	}
+
+	stdout, stderr, code := runCLI([]string{"add", "Eggs", "Bread:whole grain", "Butter", "--urgent"})
+	if code != 0 || !strings.Contains(stdout, "Added 3 items to Groceries [urgent]: Eggs, Bread (whole grain), Butter") {
+		t.Fatalf("add: exit %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+	if batches() != 1 {
+		t.Fatalf("expected one batch request, got %d", batches())
+	}
+	purchase := server.Purchase(listUUID)
+	if len(purchase) != 5 || purchase[3].ItemID != "Bread" || purchase[3].Spec != "whole grain" || !purchase[3].Attributes.Urgent {
+		t.Fatalf("unexpected purchase: %+v", purchase)
+	}
+
+	withStdin(t, "Apples\n\nPears:2 kg\n", func() {
+		stdout, stderr, code = runCLI([]string{"add", "-", "--format", "json"})
+	})
+	var added result
+	if err := json.Unmarshal([]byte(stdout), &added); code != 0 || err != nil || len(added.Results) != 2 || added.Results[1] != (itemOutcome{Item: "Pears", Spec: "2 kg"}) {
+		t.Fatalf("add from stdin: exit %d, %v, stdout %q, stderr %q", code, err, stdout, stderr)
+	}
+
+	stdout, stderr, code = runCLI([]string{"complete", "milk", "Eggs", "Bread:whole grain", "--all-matching"})
+	if code != 0 || !strings.Contains(stdout, "Completed 4 items in Groceries") {
+		t.Fatalf("complete: exit %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+	if recently := server.Recently(listUUID); len(recently) != 4 {
+		t.Fatalf("expected 4 completed items: %+v", recently)
+	}
+
+	before := batches()
+	stdout, stderr, code = runCLI([]string{"complete", "eggs", "--format", "json"})
+	var recompleted result
+	if err := json.Unmarshal([]byte(stdout), &recompleted); code != exitNotFound || err != nil || len(recompleted.Results) != 1 || recompleted.Results[0].Error == "" {
+		t.Fatalf("expected completing a recent item to report it as not found: exit %d, %v, stdout %q, stderr %q", code, err, stdout, stderr)
+	}
+	if batches() != before {
+		t.Fatal("expected no batch request for an already completed item")
+	}
+
+	withStdin(t, "Butter\nCheese\nApples\n", func() {
+		stdout, stderr, code = runCLI([]string{"remove", "-", "--format", "json"})
+	})
+	if code != exitNotFound || !strings.Contains(stderr, `"Cheese" is not on Groceries`) {
+		t.Fatalf("remove: exit %d, stderr %q", code, stderr)
+	}
+	var removed result
+	if err := json.Unmarshal([]byte(stdout), &removed); err != nil || len(removed.Results) != 3 || removed.Results[1].Error == "" || removed.Results[2].ItemUUID == "" {
+		t.Fatalf("unexpected remove result: %v: %s", err, stdout)
+	}
+	if purchase := server.Purchase(listUUID); len(purchase) != 1 || purchase[0].ItemID != "Pears" {
+		t.Fatalf("expected the found items to be removed: %+v", purchase)
+	}
+}
+
+func TestRecentAndReaddCommands(t *testing.T) {
+	server := bringtest.NewServer()
+	defer server.Close()
+	listUUID := server.AddList("Groceries")
+	server.AddItem(listUUID, "Eggs", "")
+	server.AddRecent(listUUID, "Milk", "2 L")
+	server.AddRecent(listUUID, "Bread", "whole grain")
+	server.AddRecent(listUUID, "Butter", "")
+	account := server.Account()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("BRINGS_BASE_URL", server.URL)
+	if err := saveConfig(Config{AccessToken: account.AccessToken, UserUUID: account.UUID}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	stdout, stderr, code := runCLI([]string{"recent", "--format", "json"})
+	var recent recentOutput
+	if err := json.Unmarshal([]byte(stdout), &recent); code != 0 || err != nil || len(recent.Recently) != 3 {
+		t.Fatalf("recent: exit %d, %v, stdout %q, stderr %q", code, err, stdout, stderr)
+	}
+	index := map[string]int{}
+	for _, item := range recent.Recently {
+		index[item.ItemID] = item.Index
+	}
+	stdout, _, _ = runCLI([]string{"recent"})
+	if !strings.Contains(stdout, fmt.Sprintf("%d. Bread (whole grain)", index["Bread"])) {
+		t.Fatalf("unexpected recent output: %s", stdout)
+	}
+
+	stdout, stderr, code = runCLI([]string{"readd", strconv.Itoa(index["Bread"]), "milk", "Cheese"})
+	if code != exitNotFound || !strings.Contains(stdout, "Re-added 2 items to Groceries") || !strings.Contains(stderr, `"Cheese" is not recently purchased`) {
+		t.Fatalf("readd: exit %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+	specs := map[string]string{}
+	for _, item := range server.Purchase(listUUID) {
+		specs[item.ItemID] = item.Spec
+	}
+	if len(specs) != 3 || specs["Bread"] != "whole grain" || specs["Milk"] != "2 L" {
+		t.Fatalf("expected items back with their spec: %+v", server.Purchase(listUUID))
+	}
+
+	withStdin(t, "n\n", func() {
+		_, _, code = runCLI([]string{"recent", "clear"})
+	})
+	if code != 1 || len(server.Recently(listUUID)) != 1 {
+		t.Fatalf("expected declining to keep the history, got %d", code)
+	}
+	if _, stderr, code := runCLI([]string{"recent", "clear", "--yes"}); code != 0 {
+		t.Fatalf("recent clear: exit %d: %s", code, stderr)
+	}
+	if recently := server.Recently(listUUID); len(recently) != 0 {
+		t.Fatalf("expected empty history: %+v", recently)
+	}
+	if stdout, _, _ := runCLI([]string{"recent"}); !strings.Contains(stdout, "No recently purchased items on Groceries") {
+		t.Fatalf("unexpected stdout: %s", stdout)
+	}
+}
+
+func TestWatchCommandStreamsChanges(t *testing.T) {
+	server := bringtest.NewServer()
+	defer server.Close()
+	listUUID := server.AddList("Groceries")
+	milk := server.AddItem(listUUID, "Milk", "2 L")
+	account := server.Account()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("BRINGS_BASE_URL", server.URL)
+	if err := saveConfig(Config{AccessToken: account.AccessToken, UserUUID: account.UUID}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	if _, stderr, code := runCLI([]string{"watch", "--interval", "soon"}); code != 1 || !strings.Contains(stderr, "invalid interval") {
+		t.Fatalf("expected an invalid interval to fail, got %d: %s", code, stderr)
+	}
+
+	type run struct {
+		stdout, stderr string
+		code           int
+	}
+	done := make(chan run, 1)
+	go func() {
+		stdout, stderr, code := runCLI([]string{"watch", "--interval", "20ms", "--count", "2", "--format", "json"})
+		done <- run{stdout, stderr, code}
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for baseline := false; !baseline; {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the first poll")
+		}
+		for _, request := range server.Requests() {
+			baseline = baseline || request.Path == "/bringlists/"+listUUID+"/details"
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	server.AddItem(listUUID, "Eggs", "")
+	server.UpdateItem(listUUID, milk, func(item *bringtest.Item) { item.Spec = "3 L" })
+
+	var got run
+	select {
+	case got = <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for watch to stop")
+	}
+	if got.code != 0 || !strings.Contains(got.stderr, "Watching Groceries every 20ms") {
+		t.Fatalf("watch: exit %d, stderr %q", got.code, got.stderr)
+	}
+	lines := strings.Split(strings.TrimSpace(got.stdout), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected one JSON line per change, got %q", got.stdout)
+	}
+	changes := map[bring.ChangeType]bring.ListChange{}
+	for _, line := range lines {
+		var change bring.ListChange
+		if err := json.Unmarshal([]byte(line), &change); err != nil {
+			t.Fatalf("watch output is not NDJSON: %v: %s", err, line)
+		}
+		changes[change.Type] = change
+	}
+	if added := changes[bring.ChangeAdded]; added.Item.ItemID != "Eggs" || added.ListUUID != listUUID {
+		t.Fatalf("unexpected added change: %+v", changes)
+	}
+	if changed := changes[bring.ChangeSpecChanged]; changed.Item.UUID != milk || changed.PreviousSpec != "2 L" || changed.Item.Spec != "3 L" {
+		t.Fatalf("unexpected spec change: %+v", changes)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -54,18 +55,6 @@ func TestScaleSpec(t *testing.T) {
 	}
 }
 
-func TestParseServings(t *testing.T) {
-	if got := parseServings(nil, "", 0); got != 0 {
-		t.Fatalf("unexpected servings: %d", got)
-	}
-	if got := parseServings("4"); got != 4 {
-		t.Fatalf("unexpected servings: %d", got)
-	}
-	if got := parseServings(2.0); got != 2 {
-		t.Fatalf("unexpected servings: %d", got)
-	}
-}
-
 func TestConfigPersistence(t *testing.T) {
 	tmp := t.TempDir()
 	t.Setenv("HOME", tmp)
@@ -120,3 +109,45 @@ func TestJWTExpiryLogic(t *testing.T) {
 		t.Fatalf("expected exp to be set")
 	}
 }
+
+func TestParseFlagsAgainstSchema(t *testing.T) {
+	add := findCommand("add")
+	flags, positional, err := add.parseFlags([]string{"Milk", "--spec", "-1 l", "--list=Groceries", "--urgent=false"})
+	if err != nil {
+		t.Fatalf("parseFlags failed: %v", err)
+	}
+	if len(positional) != 1 || positional[0] != "Milk" {
+		t.Fatalf("unexpected positional: %v", positional)
+	}
+	if flags.Get("spec") != "-1 l" || flags.Get("list") != "Groceries" {
+		t.Fatalf("unexpected values: %v", flags.Values)
+	}
+	if value, set := flags.Bools["urgent"]; !set || value || flags.Has("urgent") {
+		t.Fatalf("expected --urgent=false to be recorded as false: %v", flags.Bools)
+	}
+
+	lists := findCommand("lists")
+	flags, positional, err = lists.parseFlags([]string{"delete", "-yh", "--", "--odd name"})
+	if err != nil {
+		t.Fatalf("parseFlags failed: %v", err)
+	}
+	if !flags.Has("yes") || !flags.Has("help") || len(positional) != 2 || positional[1] != "--odd name" {
+		t.Fatalf("unexpected parse: %v %v", flags, positional)
+	}
+	if flags.Get("theme") != "home" {
+		t.Fatalf("expected theme default, got %q", flags.Get("theme"))
+	}
+
+	if _, _, err := add.parseFlags([]string{"Milk", "--sepc", "2%"}); err == nil || !strings.Contains(err.Error(), "did you mean --spec?") {
+		t.Fatalf("expected suggestion, got %v", err)
+	}
+	if _, _, err := lists.parseFlags([]string{"-abc"}); err == nil || !strings.Contains(err.Error(), "unknown flag -a") {
+		t.Fatalf("expected grouped short flags to be checked one by one, got %v", err)
+	}
+	if _, _, err := findCommand("recipe").parseFlags([]string{"r-1", "--servings", "many"}); err == nil {
+		t.Fatalf("expected invalid number to fail")
+	}
+	if _, _, err := add.parseFlags([]string{"Milk", "--spec"}); err == nil || !strings.Contains(err.Error(), "needs a value") {
+		t.Fatalf("expected missing value to fail, got %v", err)
+	}
+}
@@ -0,0 +1,498 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// flagType is the kind of value a flag takes.
+type flagType int
+
+const (
+	boolFlagType flagType = iota
+	stringFlagType
+	intFlagType
+)
+
+// flagSpec declares a flag accepted by a command.
+type flagSpec struct {
+	Name string
+	// Aliases are alternative names; single letters are used as -x.
+	Aliases []string
+	Type    flagType
+	// Value names the argument in help output, e.g. "<list>".
+	Value   string
+	Default string
+	Usage   string
+}
+
+// command declares a CLI command with its arguments and flags. Run receives
+// the positional arguments and the flags parsed against Flags.
+type command struct {
+	Name    string
+	Aliases []string
+	Args    string
+	Summary string
+	Flags   []flagSpec
+	Run     func(positional []string, flags FlagSet) int
+}
+
+var helpFlag = flagSpec{Name: "help", Aliases: []string{"h"}, Usage: "Show help for this command"}
+
+var listFlag = flagSpec{Name: "list", Type: stringFlagType, Value: "<list>", Usage: "List name, name prefix or UUID (default: BRINGS_LIST, defaultList, first list)"}
+
+// itemSpecFlag picks between items that share a name.
+var itemSpecFlag = flagSpec{Name: "spec", Type: stringFlagType, Value: "<text>", Usage: "Pick the item with this specification when the name is ambiguous"}
+
+var allMatchingFlag = flagSpec{Name: "all-matching", Usage: "Change every item a name matches instead of asking"}
+
+func formatFlag(defaultFormat string) flagSpec {
+	return flagSpec{Name: "format", Type: stringFlagType, Value: "<mode>", Default: defaultFormat, Usage: "Output format: json | human | pretty"}
+}
+
+func attributeFlagSpecs() []flagSpec {
+	return []flagSpec{
+		{Name: "urgent", Usage: "Mark as urgent (--urgent=false to clear)"},
+		{Name: "convenient", Usage: "Mark as convenient to buy (--convenient=false to clear)"},
+		{Name: "discounted", Usage: "Mark as discounted (--discounted=false to clear)"},
+	}
+}
+
+// commands returns the command registry in help order. Every command accepts
+// --format; those that do not declare it default to human output.
+func commands() []*command {
+	registry := []*command{
+		{
+			Name: "login", Summary: "Log in to Bring!",
+			Flags: []flagSpec{
+				{Name: "browser", Aliases: []string{"b"}, Usage: "Open a browser for login (recommended)"},
+				{Name: "token", Type: stringFlagType, Value: "<token>", Usage: "Login with an access token directly"},
+				{Name: "refresh-token", Type: stringFlagType, Value: "<token>", Usage: "Store a refresh token for automatic renewal"},
+			},
+			Run: loginCommand,
+		},
+		{
+			Name: "logout", Summary: "Clear saved credentials",
+			Run: logoutCommand,
+		},
+		{
+			Name: "status", Summary: "Show login status and token expiry",
+			Run: statusCommand,
+		},
+		{
+			Name: "lists", Args: "[create <name> | rename <list> <name> | theme <list> <theme> | delete <list>]",
+			Summary: "Show, create, rename, re-theme or delete shopping lists",
+			Flags: []flagSpec{
+				{Name: "theme", Type: stringFlagType, Value: "<theme>", Default: "home", Usage: "Theme for lists create: home, bbq, office, holiday, party"},
+				{Name: "yes", Aliases: []string{"y"}, Usage: "Delete without asking for confirmation"},
+			},
+			Run: listsCommand,
+		},
+		{
+			Name: "items", Summary: "Show items with assignee, section and photo",
+			Flags: []flagSpec{
+				listFlag,
+				{Name: "all", Usage: "Include recent/completed items"},
+				formatFlag("human"),
+				{Name: "mine", Usage: "Only items assigned to you"},
+				{Name: "assignee", Type: stringFlagType, Value: "<user>", Usage: "Only items assigned to a member (name or email)"},
+			},
+			Run: itemsCommand,
+		},
+		{
+			Name: "watch", Summary: "Print changes to a list as they happen, until interrupted",
+			Flags: []flagSpec{
+				listFlag,
+				{Name: "interval", Type: stringFlagType, Value: "<duration>", Default: "10s", Usage: "Time between polls, e.g. 30s or 2m"},
+				{Name: "count", Type: intFlagType, Value: "<n>", Usage: "Stop after n changes"},
+			},
+			Run: watchCommand,
+		},
+		{
+			Name: "add", Args: "<item[:spec]>... | -", Summary: "Add items to a list, read from stdin with -",
+			Flags: append([]flagSpec{
+				{Name: "spec", Type: stringFlagType, Value: "<text>", Usage: "Specification for items without an inline :spec"},
+				listFlag,
+			}, attributeFlagSpecs()...),
+			Run: addCommand,
+		},
+		{
+			Name: "remove", Aliases: []string{"rm"}, Args: "<item|index|uuid>... | -", Summary: "Remove items from a list",
+			Flags: []flagSpec{itemSpecFlag, allMatchingFlag, listFlag},
+			Run:   removeCommand,
+		},
+		{
+			Name: "complete", Aliases: []string{"done"}, Args: "<item|index|uuid>... | -", Summary: "Mark items as purchased",
+			Flags: []flagSpec{itemSpecFlag, allMatchingFlag, listFlag},
+			Run:   completeCommand,
+		},
+		{
+			Name: "recent", Args: "[clear]", Summary: "Show recently purchased items with indices, or clear them",
+			Flags: []flagSpec{
+				listFlag,
+				{Name: "yes", Aliases: []string{"y"}, Usage: "Clear without asking for confirmation"},
+			},
+			Run: recentCommand,
+		},
+		{
+			Name: "readd", Args: "<name|index|uuid>... | -", Summary: "Put recently purchased items back on the list with their old spec",
+			Flags: []flagSpec{listFlag},
+			Run:   readdCommand,
+		},
+		{
+			Name: "edit", Args: "<item|index|uuid>", Summary: "Change the specification or name of an item",
+			Flags: []flagSpec{
+				{Name: "spec", Type: stringFlagType, Value: "<text>", Usage: "New specification (\"\" clears it)"},
+				{Name: "append", Usage: "Add --spec to the existing specification"},
+				{Name: "name", Type: stringFlagType, Value: "<name>", Usage: "Replace the item with one of this name"},
+				listFlag,
+			},
+			Run: editCommand,
+		},
+		{
+			Name: "flag", Args: "<item>", Summary: "Change item attributes",
+			Flags: append([]flagSpec{itemSpecFlag, listFlag}, attributeFlagSpecs()...),
+			Run:   flagCommand,
+		},
+		{
+			Name: "assign", Args: "<item> <user>", Summary: "Assign an item to a list member (name or email)",
+			Flags: []flagSpec{
+				itemSpecFlag,
+				listFlag,
+				{Name: "clear", Usage: "Remove the assignment"},
+			},
+			Run: assignCommand,
+		},
+		{
+			Name: "image", Args: "set <item> <file> | rm <item>", Summary: "Attach a JPEG or PNG photo to an item, or remove it",
+			Flags: []flagSpec{itemSpecFlag, listFlag},
+			Run:   imageCommand,
+		},
+		{
+			Name: "users", Args: "[invite <email>]", Summary: "Show users sharing a list, or invite someone",
+			Flags: []flagSpec{listFlag},
+			Run:   usersCommand,
+		},
+		{
+			Name: "invites", Aliases: []string{"invitations"}, Args: "[accept|decline <invitation>]",
+			Summary: "Show or answer pending invitations (by UUID or list name)",
+			Run:     invitesCommand,
+		},
+		{
+			Name: "notify", Args: "<type>", Summary: "Notify list members: GOING_SHOPPING, CHANGED_LIST, SHOPPING_DONE, URGENT_MESSAGE",
+			Flags: []flagSpec{
+				{Name: "message", Type: stringFlagType, Value: "<text>", Usage: "Item name for URGENT_MESSAGE"},
+				listFlag,
+			},
+			Run: notifyCommand,
+		},
+		{
+			Name: "activity", Summary: "Show recent activity",
+			Flags: []flagSpec{listFlag},
+			Run:   activityCommand,
+		},
+		{
+			Name: "account", Summary: "Show account info",
+			Run: accountCommand,
+		},
+		{
+			Name: "settings", Summary: "Show user settings",
+			Run: settingsCommand,
+		},
+		{
+			Name: "config", Args: "[servings|defaultList|locale [value]]", Summary: "Show or set configuration",
+			Run: configCommand,
+		},
+		{
+			Name: "inspirations", Args: "[filter]", Summary: "List saved recipes with IDs",
+			Flags: []flagSpec{
+				formatFlag("json"),
+				{Name: "images", Aliases: []string{"image"}, Usage: "Include image URLs"},
+				{Name: "filters", Usage: "List the available filters"},
+				{Name: "verbose", Usage: "Show more details per recipe"},
+				{Name: "debug", Usage: "Print the raw API response"},
+			},
+			Run: inspirationsCommand,
+		},
+		{
+			Name: "recipe", Args: "<id>", Summary: "Show recipe details",
+			Flags: []flagSpec{
+				formatFlag("json"),
+				{Name: "images", Aliases: []string{"image"}, Usage: "Include image URLs"},
+				{Name: "servings", Type: intFlagType, Value: "<n>", Usage: "Scale for n servings (default: config servings)"},
+				{Name: "debug", Usage: "Print the raw API response"},
+			},
+			Run: recipeCommand,
+		},
+		{
+			Name: "add-recipe", Args: "<id>", Summary: "Add recipe ingredients to a list",
+			Flags: []flagSpec{
+				{Name: "servings", Type: intFlagType, Value: "<n>", Usage: "Scale for n servings (default: config servings)"},
+				{Name: "all", Usage: "Include pantry items"},
+				listFlag,
+			},
+			Run: addRecipeCommand,
+		},
+		{
+			Name: "catalog", Args: "[locale]", Summary: "Browse the item catalog",
+			Run: catalogCommand,
+		},
+	}
+	for _, cmd := range registry {
+		if _, ok := cmd.flag("format"); !ok {
+			cmd.Flags = append(cmd.Flags, formatFlag("human"))
+		}
+	}
+	return registry
+}
+
+// findCommand looks a command up by name or alias.
+func findCommand(name string) *command {
+	for _, cmd := range commands() {
+		if cmd.Name == name || slices.Contains(cmd.Aliases, name) {
+			return cmd
+		}
+	}
+	return nil
+}
+
+// flag looks a flag up by name or alias.
+func (c *command) flag(name string) (flagSpec, bool) {
+	for _, spec := range append(c.Flags, helpFlag) {
+		if spec.Name == name || slices.Contains(spec.Aliases, name) {
+			return spec, true
+		}
+	}
+	return flagSpec{}, false
+}
+
+// parseFlags splits args into flags and positional arguments according to
+// the flags c declares. Flags may be given as --name value, --name=value,
+// -x value or grouped short booleans (-yb). "--" ends flag parsing and a
+// lone "-" or a negative number is positional.
+func (c *command) parseFlags(args []string) (FlagSet, []string, error) {
+	flags := FlagSet{Values: map[string]string{}, Bools: map[string]bool{}}
+	positional := []string{}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			c.applyDefaults(flags)
+			return flags, append(positional, args[i+1:]...), nil
+		case strings.HasPrefix(arg, "--"):
+			name, value, hasValue := strings.Cut(arg[2:], "=")
+			spec, ok := c.flag(name)
+			if !ok {
+				return flags, nil, c.unknownFlag("--" + name)
+			}
+			if spec.Type != boolFlagType && !hasValue {
+				if i+1 >= len(args) {
+					return flags, nil, fmt.Errorf("flag --%s needs a value %s", spec.Name, spec.Value)
+				}
+				i++
+				value, hasValue = args[i], true
+			}
+			if err := setFlag(flags, spec, value, hasValue); err != nil {
+				return flags, nil, err
+			}
+		case len(arg) > 1 && arg[0] == '-' && !isNumber(arg):
+			shorts := arg[1:]
+			for j := 0; j < len(shorts); j++ {
+				spec, ok := c.flag(shorts[j : j+1])
+				if !ok {
+					return flags, nil, c.unknownFlag("-" + shorts[j:j+1])
+				}
+				if spec.Type == boolFlagType {
+					flags.Bools[spec.Name] = true
+					continue
+				}
+				value := shorts[j+1:]
+				if value == "" {
+					if i+1 >= len(args) {
+						return flags, nil, fmt.Errorf("flag -%s needs a value %s", shorts[j:j+1], spec.Value)
+					}
+					i++
+					value = args[i]
+				}
+				if err := setFlag(flags, spec, value, true); err != nil {
+					return flags, nil, err
+				}
+				break
+			}
+		default:
+			positional = append(positional, arg)
+		}
+	}
+	c.applyDefaults(flags)
+	return flags, positional, nil
+}
+
+func setFlag(flags FlagSet, spec flagSpec, value string, hasValue bool) error {
+	switch spec.Type {
+	case boolFlagType:
+		enabled := true
+		if hasValue {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid value for --%s: %q (use true or false)", spec.Name, value)
+			}
+			enabled = parsed
+		}
+		flags.Bools[spec.Name] = enabled
+	case intFlagType:
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("invalid value for --%s: %q (expected a number)", spec.Name, value)
+		}
+		flags.Values[spec.Name] = value
+	default:
+		flags.Values[spec.Name] = value
+	}
+	return nil
+}
+
+func (c *command) applyDefaults(flags FlagSet) {
+	for _, spec := range c.Flags {
+		if _, set := flags.Values[spec.Name]; !set && spec.Type != boolFlagType && spec.Default != "" {
+			flags.Values[spec.Name] = spec.Default
+		}
+	}
+}
+
+func (c *command) unknownFlag(flag string) error {
+	var names []string
+	for _, spec := range c.Flags {
+		names = append(names, spec.Name)
+		names = append(names, spec.Aliases...)
+	}
+	msg := fmt.Sprintf("unknown flag %s for brings %s", flag, c.Name)
+	if matches := suggest(strings.TrimLeft(flag, "-"), names); len(matches) > 0 {
+		for i, match := range matches {
+			matches[i] = "--" + match
+			if len(match) == 1 {
+				matches[i] = "-" + match
+			}
+		}
+		msg += fmt.Sprintf(" (did you mean %s?)", strings.Join(matches, " or "))
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+func isNumber(arg string) bool {
+	_, err := strconv.ParseFloat(arg, 64)
+	return err == nil
+}
+
+// suggest returns the candidates within a small edit distance of name, or
+// starting with it, closest first.
+func suggest(name string, candidates []string) []string {
+	type match struct {
+		name     string
+		distance int
+	}
+	var matches []match
+	seen := map[string]bool{}
+	for _, candidate := range candidates {
+		if seen[candidate] {
+			continue
+		}
+		seen[candidate] = true
+		distance := editDistance(name, candidate)
+		if distance <= 2 || (len(name) > 1 && strings.HasPrefix(candidate, name)) {
+			matches = append(matches, match{candidate, distance})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].distance < matches[j].distance })
+	names := make([]string, 0, len(matches))
+	for _, m := range matches {
+		names = append(names, m.name)
+	}
+	return names
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// showCommandHelp prints the usage of a single command from its schema.
+func showCommandHelp(c *command) {
+	usage := "brings " + c.Name
+	if c.Args != "" {
+		usage += " " + c.Args
+	}
+	if len(c.Flags) > 0 {
+		usage += " [flags]"
+	}
+	fmt.Printf("Usage: %s\n\n%s\n", usage, c.Summary)
+	if len(c.Aliases) > 0 {
+		fmt.Printf("\nAliases: %s\n", strings.Join(c.Aliases, ", "))
+	}
+
+	fmt.Println("\nFlags:")
+	rows := make([][2]string, 0, len(c.Flags)+1)
+	for _, spec := range append(c.Flags, helpFlag) {
+		names := make([]string, 0, len(spec.Aliases)+1)
+		for _, alias := range spec.Aliases {
+			if len(alias) == 1 {
+				names = append(names, "-"+alias)
+			}
+		}
+		names = append(names, "--"+spec.Name)
+		for _, alias := range spec.Aliases {
+			if len(alias) > 1 {
+				names = append(names, "--"+alias)
+			}
+		}
+		left := strings.Join(names, ", ")
+		if spec.Value != "" {
+			left += " " + spec.Value
+		}
+		right := spec.Usage
+		if spec.Default != "" {
+			right += fmt.Sprintf(" (default: %s)", spec.Default)
+		}
+		rows = append(rows, [2]string{left, right})
+	}
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row[0]))
+	}
+	for _, row := range rows {
+		fmt.Printf("  %-*s  %s\n", width, row[0], row[1])
+	}
+}
+
+// unknownCommand reports a command that is not in the registry.
+func unknownCommand(name string) int {
+	var names []string
+	for _, cmd := range commands() {
+		names = append(names, cmd.Name)
+	}
+	fmt.Fprintf(os.Stderr, "Unknown command: %s\n", name)
+	if matches := suggest(name, names); len(matches) > 0 {
+		fmt.Fprintf(os.Stderr, "Did you mean: %s?\n", strings.Join(matches, ", "))
+	}
+	fmt.Fprintln(os.Stderr, "Run `brings --help` for usage")
+	return 1
+}
@@ -8,6 +8,7 @@ import (
 
 type Config struct {
 	AccessToken    string `json:"accessToken"`
+	RefreshToken   string `json:"refreshToken,omitempty"`
 	UserUUID       string `json:"userUuid"`
 	PublicUserUUID string `json:"publicUserUuid"`
 	UserName       string `json:"userName"`
@@ -25,6 +26,10 @@ func getConfigDir() string {
 	return filepath.Join(home, ".config", "brings")
 }
 
+func getCacheDir() string {
+	return filepath.Join(getConfigDir(), "cache")
+}
+
 func getConfigPath() string {
 	return filepath.Join(getConfigDir(), "config.json")
 }
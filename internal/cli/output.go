@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/benithors/brings-cli/bring"
+)
+
+// output renders command results in the mode chosen with --format. In JSON
+// modes stdout carries exactly one JSON document; progress and warnings go
+// to stderr.
+type output struct {
+	json   bool
+	pretty bool
+}
+
+// outputFor returns the output for flags. Run has already rejected invalid
+// --format values, so parse errors cannot happen here.
+func outputFor(flags FlagSet) output {
+	format, pretty, _ := parseOutputFormat(flags, "human")
+	return output{json: format == "json", pretty: pretty}
+}
+
+// print writes value as JSON, or calls human to print it as text.
+func (o output) print(value interface{}, human func()) int {
+	if o.json {
+		printJSON(value, o.pretty)
+		return 0
+	}
+	human()
+	return 0
+}
+
+// result reports what a mutating command changed.
+func (o output) result(r result) int {
+	return o.print(r, func() { fmt.Println(r.Message) })
+}
+
+// progress prints status lines that are not part of the result.
+func (o output) progress(format string, args ...interface{}) {
+	if o.json {
+		fmt.Fprintf(os.Stderr, format, args...)
+		return
+	}
+	fmt.Printf(format, args...)
+}
+
+// result is the JSON object printed by commands that change something.
+// Action names the change, e.g. "add" or "list.rename"; the other fields are
+// set when they apply to it. Message is the line printed in human mode.
+type result struct {
+	Action       string                   `json:"action"`
+	ListUUID     string                   `json:"listUuid,omitempty"`
+	List         string                   `json:"list,omitempty"`
+	Previous     string                   `json:"previous,omitempty"`
+	Theme        string                   `json:"theme,omitempty"`
+	Item         string                   `json:"item,omitempty"`
+	ItemUUID     string                   `json:"itemUuid,omitempty"`
+	Spec         string                   `json:"spec,omitempty"`
+	Attributes   *bring.ItemAttributes    `json:"attributes,omitempty"`
+	ImageURL     string                   `json:"imageUrl,omitempty"`
+	User         string                   `json:"user,omitempty"`
+	UserUUID     string                   `json:"userUuid,omitempty"`
+	Invitation   string                   `json:"invitation,omitempty"`
+	Notification string                   `json:"notification,omitempty"`
+	Text         string                   `json:"text,omitempty"`
+	Key          string                   `json:"key,omitempty"`
+	Value        string                   `json:"value,omitempty"`
+	Recipe       string                   `json:"recipe,omitempty"`
+	RecipeUUID   string                   `json:"recipeUuid,omitempty"`
+	Items        []recipeIngredientOutput `json:"items,omitempty"`
+	Skipped      int                      `json:"skipped,omitempty"`
+	Results      []itemOutcome            `json:"results,omitempty"`
+	Message      string                   `json:"message"`
+}
+
+// itemOutcome reports what happened to one item of add, remove or complete.
+// Error is set when the item could not be resolved and was left out.
+type itemOutcome struct {
+	Item     string `json:"item"`
+	Spec     string `json:"spec,omitempty"`
+	ItemUUID string `json:"itemUuid,omitempty"`
+	Error    string `json:"error,omitempty"`
+}